@@ -0,0 +1,85 @@
+package datetime
+
+import "time"
+
+//AsOfDirection decides which right timestamp AsOfJoin matches to a left timestamp
+type AsOfDirection int
+
+const (
+	//AsOfBackward matches the last right timestamp at or before the left one
+	AsOfBackward AsOfDirection = iota
+	//AsOfForward matches the first right timestamp at or after the left one
+	AsOfForward
+	//AsOfNearest matches the closest right timestamp, the earlier one on ties
+	AsOfNearest
+)
+
+//AsOfOptions configures AsOfJoin
+type AsOfOptions struct {
+	Direction AsOfDirection
+	//Tolerance is the maximum distance between matched timestamps, 0 means no limit
+	Tolerance time.Duration
+	//ExcludeExactMatches prevents matching right timestamps equal to the left one
+	ExcludeExactMatches bool
+}
+
+//AsOfJoin returns for each time in leftIndex the position in rightIndex of the time it aligns to, or -1 if there is none
+//by default the last right time at or before each left time is matched, like the latest quote for a trade
+//both indices must be sorted, and are walked once so it runs in O(n+m)
+func AsOfJoin(leftIndex, rightIndex []time.Time, opts ...AsOfOptions) ([]int, error) {
+	var opt AsOfOptions
+	if opts != nil {
+		opt = opts[0]
+	}
+	if err := checkSorted("AsOfJoin", "left index", leftIndex); err != nil {
+		return nil, err
+	}
+	if err := checkSorted("AsOfJoin", "right index", rightIndex); err != nil {
+		return nil, err
+	}
+	within := func(d time.Duration) bool {
+		return opt.Tolerance <= 0 || d <= opt.Tolerance
+	}
+
+	matches := make([]int, len(leftIndex))
+	//before is the number of right times before the left time, atOrBefore includes equal ones
+	before, atOrBefore := 0, 0
+	for i, l := range leftIndex {
+		for before < len(rightIndex) && rightIndex[before].Before(l) {
+			before++
+		}
+		if atOrBefore < before {
+			atOrBefore = before
+		}
+		for atOrBefore < len(rightIndex) && !rightIndex[atOrBefore].After(l) {
+			atOrBefore++
+		}
+
+		prev, next := atOrBefore-1, before
+		if opt.ExcludeExactMatches {
+			prev, next = before-1, atOrBefore
+		}
+		hasPrev := prev >= 0 && within(l.Sub(rightIndex[prev]))
+		hasNext := next < len(rightIndex) && within(rightIndex[next].Sub(l))
+
+		matches[i] = -1
+		switch opt.Direction {
+		case AsOfForward:
+			if hasNext {
+				matches[i] = next
+			}
+		case AsOfNearest:
+			switch {
+			case hasPrev && (!hasNext || l.Sub(rightIndex[prev]) <= rightIndex[next].Sub(l)):
+				matches[i] = prev
+			case hasNext:
+				matches[i] = next
+			}
+		default:
+			if hasPrev {
+				matches[i] = prev
+			}
+		}
+	}
+	return matches, nil
+}
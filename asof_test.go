@@ -0,0 +1,48 @@
+package datetime
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAsOfJoin(t *testing.T) {
+	start := time.Date(2021, 1, 1, 9, 15, 0, 0, time.UTC)
+	at := func(minutes ...int) []time.Time {
+		t := []time.Time{}
+		for _, m := range minutes {
+			t = append(t, start.Add(time.Minute*time.Duration(m)))
+		}
+		return t
+	}
+	left := at(0, 2, 5, 9, 20)
+	right := at(1, 2, 6, 10)
+	tests := []struct {
+		name    string
+		opts    []AsOfOptions
+		want    []int
+		wantErr bool
+	}{
+		{"backward", nil, []int{-1, 1, 1, 2, 3}, false},
+		{"backward exclude exact", []AsOfOptions{{ExcludeExactMatches: true}}, []int{-1, 0, 1, 2, 3}, false},
+		{"backward tolerance", []AsOfOptions{{Tolerance: time.Minute * 3}}, []int{-1, 1, 1, 2, -1}, false},
+		{"forward", []AsOfOptions{{Direction: AsOfForward}}, []int{0, 1, 2, 3, -1}, false},
+		{"forward exclude exact", []AsOfOptions{{Direction: AsOfForward, ExcludeExactMatches: true}}, []int{0, 2, 2, 3, -1}, false},
+		{"nearest", []AsOfOptions{{Direction: AsOfNearest}}, []int{0, 1, 2, 3, 3}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AsOfJoin(left, right, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AsOfJoin() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AsOfJoin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, err := AsOfJoin(at(2, 1), right); err == nil {
+		t.Errorf("AsOfJoin() expected error for unsorted left index")
+	}
+}
@@ -0,0 +1,166 @@
+package datetime
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+//DayPolicy decides what Build does when the day of month does not exist in the built month, like February 31st
+type DayPolicy int
+
+const (
+	//DayStrict reports an error for days that do not exist in the month
+	DayStrict DayPolicy = iota
+	//DayClamp moves days past the end of the month to the last day of the month
+	DayClamp
+	//DayOverflow rolls days past the end of the month into the next month, like time.Date does
+	DayOverflow
+)
+
+//FieldErrors holds every invalid field found while building a time
+type FieldErrors []error
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+//Builder sets individual fields of a time.Time. Fields which are not set keep the value they had in the original time
+//Create one using With, chain setters and call Build
+//
+//	t, err := With(t).Year(2021).Month(time.February).Day(29).Build()
+type Builder struct {
+	t      time.Time
+	year   int
+	month  time.Month
+	day    int
+	hour   int
+	minute int
+	second int
+	nsec   int
+	loc    *time.Location
+	policy DayPolicy
+	errs   FieldErrors
+}
+
+//With starts a Builder from t. By default the DayStrict policy is used
+func With(t time.Time) *Builder {
+	return &Builder{
+		t:      t,
+		year:   t.Year(),
+		month:  t.Month(),
+		day:    t.Day(),
+		hour:   t.Hour(),
+		minute: t.Minute(),
+		second: t.Second(),
+		nsec:   t.Nanosecond(),
+		loc:    t.Location(),
+	}
+}
+
+func (b *Builder) check(field string, value, min, max int) bool {
+	if value < min || value > max {
+		b.errs = append(b.errs, fmt.Errorf("invalid %v %v: must be between %v and %v", field, value, min, max))
+		return false
+	}
+	return true
+}
+
+//Year sets the year
+func (b *Builder) Year(year int) *Builder {
+	b.year = year
+	return b
+}
+
+//Month sets the month, which must be between 1 and 12
+func (b *Builder) Month(month time.Month) *Builder {
+	if b.check("month", int(month), 1, 12) {
+		b.month = month
+	}
+	return b
+}
+
+//Day sets the day of month, which must be between 1 and 31. Whether it exists in the month is checked by Build according to the DayPolicy
+func (b *Builder) Day(day int) *Builder {
+	if b.check("day", day, 1, 31) {
+		b.day = day
+	}
+	return b
+}
+
+//Hour sets the hour, which must be between 0 and 23
+func (b *Builder) Hour(hour int) *Builder {
+	if b.check("hour", hour, 0, 23) {
+		b.hour = hour
+	}
+	return b
+}
+
+//Minute sets the minute, which must be between 0 and 59
+func (b *Builder) Minute(minute int) *Builder {
+	if b.check("minute", minute, 0, 59) {
+		b.minute = minute
+	}
+	return b
+}
+
+//Second sets the second, which must be between 0 and 59
+func (b *Builder) Second(second int) *Builder {
+	if b.check("second", second, 0, 59) {
+		b.second = second
+	}
+	return b
+}
+
+//Nanosecond sets the nanosecond, which must be between 0 and 999999999
+func (b *Builder) Nanosecond(nsec int) *Builder {
+	if b.check("nanosecond", nsec, 0, 999999999) {
+		b.nsec = nsec
+	}
+	return b
+}
+
+//Location sets the location without adjusting any other field, like StripTimezone does for UTC
+func (b *Builder) Location(loc *time.Location) *Builder {
+	if loc == nil {
+		b.errs = append(b.errs, fmt.Errorf("invalid location: must not be nil"))
+		return b
+	}
+	b.loc = loc
+	return b
+}
+
+//Policy sets the DayPolicy used by Build
+func (b *Builder) Policy(policy DayPolicy) *Builder {
+	b.policy = policy
+	return b
+}
+
+//Build validates the combination of fields and returns the resulting time
+//All invalid fields are reported together as FieldErrors, in which case the original time is returned
+func (b *Builder) Build() (time.Time, error) {
+	errs := append(FieldErrors{}, b.errs...)
+	day := b.day
+	if last := DaysInMonth(b.year, b.month); day > last {
+		switch b.policy {
+		case DayClamp:
+			day = last
+		case DayOverflow:
+		default:
+			errs = append(errs, fmt.Errorf("invalid day %v: %v %v has %v days", day, b.month, b.year, last))
+		}
+	}
+	if len(errs) != 0 {
+		return b.t, errs
+	}
+	return time.Date(b.year, b.month, day, b.hour, b.minute, b.second, b.nsec, b.loc), nil
+}
+
+//DaysInMonth returns the number of days in month of year
+func DaysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
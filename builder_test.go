@@ -0,0 +1,62 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuilderBuild(t *testing.T) {
+	base := time.Date(2020, time.January, 31, 9, 15, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		builder  *Builder
+		want     time.Time
+		wantErrs int
+	}{
+		{"all fields", With(base).Year(2021).Month(time.March).Day(4).Hour(10).Minute(30).Second(5).Nanosecond(7), time.Date(2021, time.March, 4, 10, 30, 5, 7, time.UTC), 0},
+		{"strict feb 31", With(base).Month(time.February), base, 1},
+		{"clamp feb 31", With(base).Month(time.February).Policy(DayClamp), time.Date(2020, time.February, 29, 9, 15, 0, 0, time.UTC), 0},
+		{"overflow feb 31", With(base).Month(time.February).Policy(DayOverflow), time.Date(2020, time.March, 2, 9, 15, 0, 0, time.UTC), 0},
+		{"leap day into non leap year", With(time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)).Year(2021), time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC), 1},
+		{"all errors reported", With(base).Month(13).Hour(24).Minute(-1), base, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.builder.Build()
+			gotErrs := 0
+			if err != nil {
+				gotErrs = len(err.(FieldErrors))
+			}
+			if gotErrs != tt.wantErrs {
+				t.Errorf("Builder.Build() error = %v, want %v errors", err, tt.wantErrs)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Builder.Build() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceFunctions(t *testing.T) {
+	base := time.Date(2020, time.January, 31, 9, 15, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"replace year", ReplaceYear(base, 2021), time.Date(2021, time.January, 31, 9, 15, 0, 0, time.UTC)},
+		{"replace month", ReplaceMonth(base, 3), time.Date(2020, time.March, 31, 9, 15, 0, 0, time.UTC)},
+		{"replace month zero", ReplaceMonth(base, 0), base},
+		{"replace month day missing in month", ReplaceMonth(base, 2), base},
+		{"replace day not rolled", ReplaceDay(time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC), 31), time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"replace hour", ReplaceHour(base, 23), time.Date(2020, time.January, 31, 23, 15, 0, 0, time.UTC)},
+		{"replace nanosecond invalid", ReplaceNanosecond(base, -1), base},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
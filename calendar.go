@@ -0,0 +1,49 @@
+package datetime
+
+import "time"
+
+//ISOWeek returns the ISO 8601 year and week number in which t occurs
+//weeks start on Monday and week 1 is the week containing the first Thursday of the year
+func ISOWeek(t time.Time) (year, week int) {
+	return t.ISOWeek()
+}
+
+//WeekStart returns midnight of the first day of the week containing t
+//firstDay is the weekday a week starts on, if not provided it defaults to time.Monday as in ISO 8601
+func WeekStart(t time.Time, firstDay ...time.Weekday) time.Time {
+	first := time.Monday
+	if firstDay != nil {
+		first = firstDay[0]
+	}
+	back := (int(t.Weekday()) - int(first) + 7) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-back, 0, 0, 0, 0, t.Location())
+}
+
+//WeekOfYear returns the week number of t within its calendar year
+//if firstDay is not provided or is time.Monday, the ISO 8601 week number is returned (see ISOWeek)
+//for any other firstDay, week 1 is the week containing January 1st and weeks start on firstDay
+func WeekOfYear(t time.Time, firstDay ...time.Weekday) int {
+	if firstDay == nil || firstDay[0] == time.Monday {
+		_, week := t.ISOWeek()
+		return week
+	}
+	yearStart := WeekStart(time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location()), firstDay[0])
+	days := int(ExtractDateFromDatetime(t).Sub(yearStart).Hours()+12) / 24
+	return days/7 + 1
+}
+
+//Quarter returns the calendar quarter of t, from 1 to 4
+func Quarter(t time.Time) int {
+	return (int(t.Month())-1)/3 + 1
+}
+
+//QuarterStart returns midnight of the first day of the quarter containing t
+func QuarterStart(t time.Time) time.Time {
+	return time.Date(t.Year(), time.Month((Quarter(t)-1)*3+1), 1, 0, 0, 0, 0, t.Location())
+}
+
+//QuarterEnd returns the start of the quarter following the one containing t
+//the end is exclusive so that DatetimeIsInRange(t, QuarterStart(t), QuarterEnd(t)) is always true
+func QuarterEnd(t time.Time) time.Time {
+	return QuarterStart(t).AddDate(0, 3, 0)
+}
@@ -0,0 +1,79 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekStart(t *testing.T) {
+	type args struct {
+		t        time.Time
+		firstDay []time.Weekday
+	}
+	tests := []struct {
+		name string
+		args args
+		want time.Time
+	}{
+		{"monday default", args{time.Date(2021, 3, 4, 9, 15, 0, 0, time.UTC), nil}, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"sunday start", args{time.Date(2021, 3, 4, 9, 15, 0, 0, time.UTC), []time.Weekday{time.Sunday}}, time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"already week start", args{time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), nil}, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeekStart(tt.args.t, tt.args.firstDay...); !got.Equal(tt.want) {
+				t.Errorf("WeekStart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeekOfYear(t *testing.T) {
+	type args struct {
+		t        time.Time
+		firstDay []time.Weekday
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{"iso week 53 of previous year", args{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), nil}, 53},
+		{"iso mid year", args{time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC), nil}, 9},
+		{"sunday jan 1", args{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), []time.Weekday{time.Sunday}}, 1},
+		{"sunday second week", args{time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC), []time.Weekday{time.Sunday}}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeekOfYear(tt.args.t, tt.args.firstDay...); got != tt.want {
+				t.Errorf("WeekOfYear() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuarterBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		t         time.Time
+		quarter   int
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{"q1", time.Date(2021, 2, 14, 10, 0, 0, 0, time.UTC), 1, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"q4", time.Date(2021, 12, 31, 23, 59, 0, 0, time.UTC), 4, time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Quarter(tt.t); got != tt.quarter {
+				t.Errorf("Quarter() = %v, want %v", got, tt.quarter)
+			}
+			if got := QuarterStart(tt.t); !got.Equal(tt.wantStart) {
+				t.Errorf("QuarterStart() = %v, want %v", got, tt.wantStart)
+			}
+			if got := QuarterEnd(tt.t); !got.Equal(tt.wantEnd) {
+				t.Errorf("QuarterEnd() = %v, want %v", got, tt.wantEnd)
+			}
+		})
+	}
+}
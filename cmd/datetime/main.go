@@ -0,0 +1,317 @@
+//Command datetime exposes the parsing, conversion, range generation and bucketing of the datetime package on the command line
+//
+//	datetime parse [--tz zone] [--layout YYYY-MM-DD] [--format layout] [--json] <datetime>
+//	datetime interval [--json] <interval>
+//	datetime range --start <datetime> --end <datetime> --interval <interval> [--inclusive] [--tz zone] [--format layout] [--json]
+//	datetime bucket --interval <interval> [--start <datetime>] [--empty] [--tz zone] [--format layout] [--json] < timestamps
+//	datetime convert --to <zone> [--from zone] [--format layout] [--json] <datetime>
+//
+//--format is rfc3339 (the default), unix, unixms, or a YYYY-MM-DD hh:mm:ss like layout
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	datetime "github.com/devshoe/datetime-go"
+)
+
+const usage = `usage: datetime <command> [flags] [args]
+
+commands:
+  parse     parse a datetime in any supported format
+  interval  parse an interval like 15m, 1day or hour
+  range     print times between --start and --end spaced by --interval
+  bucket    read timestamps from stdin, one per line, and print bucket boundaries and counts
+  convert   convert a datetime to another timezone
+
+run datetime <command> -h for the flags of a command
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+//run executes the command in args and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	commands := map[string]func([]string, io.Reader, io.Writer, io.Writer) error{
+		"parse":    parseCommand,
+		"interval": intervalCommand,
+		"range":    rangeCommand,
+		"bucket":   bucketCommand,
+		"convert":  convertCommand,
+	}
+	command, exists := commands[args[0]]
+	if !exists {
+		fmt.Fprintf(stderr, "datetime: unknown command %q\n\n%v", args[0], usage)
+		return 2
+	}
+	if err := command(args[1:], stdin, stdout, stderr); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintf(stderr, "datetime %v: %v\n", args[0], err)
+		}
+		return 1
+	}
+	return 0
+}
+
+//outputFlags are the flags shared by every command printing times
+type outputFlags struct {
+	format string
+	json   bool
+}
+
+func (o *outputFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.format, "format", "rfc3339", "output format: rfc3339, unix, unixms or a YYYY-MM-DD hh:mm:ss like layout")
+	fs.BoolVar(&o.json, "json", false, "print JSON instead of text")
+}
+
+func (o *outputFlags) formatTime(t time.Time) string {
+	switch strings.ToLower(o.format) {
+	case "rfc3339", "":
+		return t.Format(time.RFC3339Nano)
+	case "unix":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "unixms":
+		return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+	default:
+		return datetime.FlexTimeConfig{OutputLayout: o.format}.Format(t)
+	}
+}
+
+//print writes v as JSON if requested, otherwise the text lines
+func (o *outputFlags) print(w io.Writer, v interface{}, lines ...string) error {
+	if o.json {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//parseFlags parses flags placed before or after positional arguments, and checks the number of positional arguments
+//flag errors and help are written to stderr
+func parseFlags(fs *flag.FlagSet, stderr io.Writer, args []string, positional int) ([]string, error) {
+	fs.SetOutput(stderr)
+	rest := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			//the flag package has already reported the error
+			return nil, flag.ErrHelp
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		rest = append(rest, args[0])
+		args = args[1:]
+	}
+	if len(rest) != positional {
+		return nil, fmt.Errorf("expected %v arguments, got %v", positional, len(rest))
+	}
+	return rest, nil
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
+}
+
+func parseCommand(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("parse", flag.ContinueOnError)
+	tz := fs.String("tz", "", "timezone of datetimes without one, UTC if empty")
+	layout := fs.String("layout", "", "YYYY-MM-DD hh:mm:ss like layout of the input, detected if empty")
+	var out outputFlags
+	out.register(fs)
+	rest, err := parseFlags(fs, stderr, args, 1)
+	if err != nil {
+		return err
+	}
+	loc, err := loadLocation(*tz)
+	if err != nil {
+		return err
+	}
+	var t time.Time
+	if *layout != "" {
+		if t, err = datetime.ParseDatetimeWithYYMMDDLikeLayout(rest[0], *layout); err == nil && *tz != "" {
+			t, err = datetime.With(t).Location(loc).Build()
+		}
+	} else {
+		t, err = datetime.FlexTimeConfig{Location: loc}.Parse(rest[0])
+	}
+	if err != nil {
+		return err
+	}
+	formatted := out.formatTime(t)
+	return out.print(stdout, map[string]string{"input": rest[0], "time": formatted}, formatted)
+}
+
+func intervalCommand(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("interval", flag.ContinueOnError)
+	var out outputFlags
+	out.register(fs)
+	rest, err := parseFlags(fs, stderr, args, 1)
+	if err != nil {
+		return err
+	}
+	interval, err := datetime.ParseIntervalValue(rest[0])
+	if err != nil {
+		return err
+	}
+	seconds := interval.Duration().Seconds()
+	return out.print(stdout, map[string]interface{}{"input": rest[0], "interval": interval, "seconds": seconds},
+		fmt.Sprintf("%v (%v seconds)", interval, strconv.FormatFloat(seconds, 'f', -1, 64)))
+}
+
+func rangeCommand(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("range", flag.ContinueOnError)
+	start := fs.String("start", "", "first datetime of the range")
+	end := fs.String("end", "", "datetime ending the range")
+	var interval datetime.Interval
+	fs.Var(&interval, "interval", "spacing of the range, like 15m, negative to go backwards")
+	inclusive := fs.Bool("inclusive", false, "include the end datetime if it falls on the range")
+	tz := fs.String("tz", "", "timezone of datetimes without one, UTC if empty")
+	var out outputFlags
+	out.register(fs)
+	if _, err := parseFlags(fs, stderr, args, 0); err != nil {
+		return err
+	}
+	loc, err := loadLocation(*tz)
+	if err != nil {
+		return err
+	}
+	config := datetime.FlexTimeConfig{Location: loc}
+	startTime, err := config.Parse(*start)
+	if err != nil {
+		return fmt.Errorf("--start: %v", err)
+	}
+	endTime, err := config.Parse(*end)
+	if err != nil {
+		return fmt.Errorf("--end: %v", err)
+	}
+	it, err := datetime.NewTimeIterator(startTime, endTime, interval.Duration(), *inclusive)
+	if err != nil {
+		return err
+	}
+	if out.json {
+		formatted := []string{}
+		for it.Next() {
+			formatted = append(formatted, out.formatTime(it.Time()))
+		}
+		return out.print(stdout, formatted)
+	}
+	w := bufio.NewWriter(stdout)
+	for it.Next() {
+		fmt.Fprintln(w, out.formatTime(it.Time()))
+	}
+	return w.Flush()
+}
+
+func bucketCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("bucket", flag.ContinueOnError)
+	var interval datetime.Interval
+	fs.Var(&interval, "interval", "bucketing interval, like 15m")
+	start := fs.String("start", "", "datetime to start splitting from, the first timestamp if empty")
+	empty := fs.Bool("empty", false, "print buckets without timestamps too")
+	tz := fs.String("tz", "", "timezone of datetimes without one, UTC if empty")
+	var out outputFlags
+	out.register(fs)
+	if _, err := parseFlags(fs, stderr, args, 0); err != nil {
+		return err
+	}
+	loc, err := loadLocation(*tz)
+	if err != nil {
+		return err
+	}
+	config := datetime.FlexTimeConfig{Location: loc}
+	var startTime []time.Time
+	if *start != "" {
+		t, err := config.Parse(*start)
+		if err != nil {
+			return fmt.Errorf("--start: %v", err)
+		}
+		startTime = append(startTime, t)
+	}
+
+	index := []time.Time{}
+	scanner := bufio.NewScanner(stdin)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		t, err := config.Parse(text)
+		if err != nil {
+			return fmt.Errorf("line %v: %v", line, err)
+		}
+		index = append(index, t)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	buckets, err := datetime.BucketIndexByInterval(index, interval.Duration(), *empty, startTime...)
+	if err != nil {
+		return err
+	}
+
+	type bucket struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+		Count int    `json:"count"`
+	}
+	rows := make([]bucket, 0, len(buckets))
+	lines := make([]string, 0, len(buckets))
+	for _, b := range buckets {
+		row := bucket{out.formatTime(b.Start), out.formatTime(b.End), b.Count}
+		rows = append(rows, row)
+		lines = append(lines, fmt.Sprintf("%v\t%v\t%v", row.Start, row.End, row.Count))
+	}
+	return out.print(stdout, rows, lines...)
+}
+
+func convertCommand(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	from := fs.String("from", "", "timezone of datetimes without one, UTC if empty")
+	to := fs.String("to", "", "timezone to convert to")
+	var out outputFlags
+	out.register(fs)
+	rest, err := parseFlags(fs, stderr, args, 1)
+	if err != nil {
+		return err
+	}
+	if *to == "" {
+		return fmt.Errorf("--to is required")
+	}
+	fromLoc, err := loadLocation(*from)
+	if err != nil {
+		return err
+	}
+	toLoc, err := loadLocation(*to)
+	if err != nil {
+		return err
+	}
+	t, err := datetime.FlexTimeConfig{Location: fromLoc}.Parse(rest[0])
+	if err != nil {
+		return err
+	}
+	formatted := out.formatTime(t.In(toLoc))
+	return out.print(stdout, map[string]string{"input": rest[0], "time": formatted, "zone": toLoc.String()}, formatted)
+}
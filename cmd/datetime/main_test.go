@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		want     string
+		wantCode int
+	}{
+		{"parse with tz", []string{"parse", "--tz", "Asia/Kolkata", "2021-03-04 09:15"}, "", "2021-03-04T09:15:00+05:30\n", 0},
+		{"parse flags after argument", []string{"parse", "2021-03-04", "--format", "unix"}, "", "1614816000\n", 0},
+		{"parse with layout", []string{"parse", "--layout", "DD/MM/YYYY", "04/03/2021"}, "", "2021-03-04T00:00:00Z\n", 0},
+		{"parse json", []string{"parse", "--json", "2021-03-04"}, "", "{\n  \"input\": \"2021-03-04\",\n  \"time\": \"2021-03-04T00:00:00Z\"\n}\n", 0},
+		{"interval", []string{"interval", "1day"}, "", "1d (86400 seconds)\n", 0},
+		{"range", []string{"range", "--start", "2021-01-01", "--end", "2021-01-01 01:00", "--interval", "20m", "--format", "hh:mm"}, "", "00:00\n00:20\n00:40\n", 0},
+		{"range inclusive json", []string{"range", "--start", "2021-01-01", "--end", "2021-01-01 00:10", "--interval", "10m", "--inclusive", "--json"}, "", "[\n  \"2021-01-01T00:00:00Z\",\n  \"2021-01-01T00:10:00Z\"\n]\n", 0},
+		{"bucket", []string{"bucket", "--interval", "5m", "--format", "hh:mm"}, "2020-12-12 09:15:00\n2020-12-12 09:17:00\n\n2020-12-12 09:31:00\n", "09:15\t09:20\t2\n09:30\t09:35\t1\n", 0},
+		{"convert", []string{"convert", "--to", "America/New_York", "2021-03-04T09:15:00Z"}, "", "2021-03-04T04:15:00-05:00\n", 0},
+		{"unknown command", []string{"frobnicate"}, "", "", 2},
+		{"invalid datetime", []string{"parse", "20 12-12"}, "", "", 1},
+		{"zero interval", []string{"range", "--start", "2021-01-01", "--end", "2021-01-02", "--interval", "0s"}, "", "", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Errorf("run() = %v, want %v, stderr: %v", code, tt.wantCode, stderr.String())
+			}
+			if stdout.String() != tt.want {
+				t.Errorf("run() stdout = %q, want %q", stdout.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package datetime
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/araddon/dateparse"
+)
+
+//Frame is a set of float64 columns indexed by time, as read from a CSV file
+type Frame struct {
+	//TimeName is the header of the time column
+	TimeName string
+	Index    []time.Time
+	//Names are the headers of the value columns, in file order
+	Names   []string
+	Columns map[string][]float64
+}
+
+//Len returns the number of rows
+func (f *Frame) Len() int {
+	return len(f.Index)
+}
+
+//Column returns the values of the column named name, nil if there is none
+func (f *Frame) Column(name string) []float64 {
+	return f.Columns[name]
+}
+
+//CSVOptions configures reading a CSV file. The first line of the file must be a header
+type CSVOptions struct {
+	//TimeColumn is the header of the time column. If empty, TimeColumnIndex is used
+	TimeColumn      string
+	TimeColumnIndex int
+	//Layout is a YYYY-MM-DD hh:mm:ss like layout for the time column. If empty, it is detected from the first row,
+	//falling back to ParseDatetime for rows it does not match
+	Layout string
+	//Location is used for times without a timezone, nil means UTC
+	Location *time.Location
+	//Columns are the headers of the value columns to read, if nil every column other than the time column is read
+	Columns []string
+	//Comma is the field delimiter, ',' if 0
+	Comma rune
+}
+
+//CSVReader streams rows of a CSV file, parsing the time column and converting value columns to float64
+//values in DefaultNullTokens are read as NaN. It implements Iterator
+//
+//	for r.Next() {
+//		t, values := r.Time(), r.Values()
+//	}
+//	if r.Err() != nil {
+//	}
+type CSVReader struct {
+	csv      *csv.Reader
+	location *time.Location
+	layout   string
+	timeName string
+	timeCol  int
+	names    []string
+	cols     []int
+	row      int
+	time     time.Time
+	values   []float64
+	err      error
+}
+
+//NewCSVReader reads the header from r and prepares to stream rows
+func NewCSVReader(r io.Reader, opts CSVOptions) (*CSVReader, error) {
+	reader := &CSVReader{csv: csv.NewReader(r), location: opts.Location, layout: opts.Layout}
+	if opts.Comma != 0 {
+		reader.csv.Comma = opts.Comma
+	}
+	reader.csv.ReuseRecord = true
+	if reader.location == nil {
+		reader.location = time.UTC
+	}
+	if reader.layout != "" {
+		reader.layout = convertLayoutToGolangLayout(reader.layout)
+	}
+
+	header, err := reader.csv.Read()
+	if err != nil {
+		return nil, fmt.Errorf("(NewCSVReader) failed to read header: %v", err)
+	}
+	reader.timeCol = opts.TimeColumnIndex
+	if opts.TimeColumn != "" {
+		reader.timeCol = indexOf(header, opts.TimeColumn)
+	}
+	if reader.timeCol < 0 || reader.timeCol >= len(header) {
+		return nil, fmt.Errorf("(NewCSVReader) time column %q (%v) not found in header %v", opts.TimeColumn, opts.TimeColumnIndex, header)
+	}
+	reader.timeName = header[reader.timeCol]
+
+	if opts.Columns == nil {
+		for i, name := range header {
+			if i != reader.timeCol {
+				reader.names = append(reader.names, name)
+				reader.cols = append(reader.cols, i)
+			}
+		}
+	} else {
+		for _, name := range opts.Columns {
+			i := indexOf(header, name)
+			if i < 0 {
+				return nil, fmt.Errorf("(NewCSVReader) column %q not found in header %v", name, header)
+			}
+			reader.names = append(reader.names, name)
+			reader.cols = append(reader.cols, i)
+		}
+	}
+	return reader, nil
+}
+
+func indexOf(header []string, name string) int {
+	for i := range header {
+		if header[i] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+//Names returns the headers of the value columns, in the order of Values
+func (r *CSVReader) Names() []string {
+	return r.names
+}
+
+//Next reads the next row, returning false at the end of the file or on error, see Err
+func (r *CSVReader) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	record, err := r.csv.Read()
+	if err == io.EOF {
+		return false
+	}
+	if err != nil {
+		r.err = err
+		return false
+	}
+	r.row++
+	if r.time, err = r.parseTime(record[r.timeCol]); err != nil {
+		r.err = fmt.Errorf("(CSVReader) row %v: %v", r.row, err)
+		return false
+	}
+	r.values = make([]float64, len(r.cols))
+	for i, col := range r.cols {
+		if isNullToken(record[col], DefaultNullTokens) {
+			r.values[i] = math.NaN()
+			continue
+		}
+		if r.values[i], err = strconv.ParseFloat(record[col], 64); err != nil {
+			r.err = fmt.Errorf("(CSVReader) row %v: column %q is not numeric: %v", r.row, r.names[i], err)
+			return false
+		}
+	}
+	return true
+}
+
+func (r *CSVReader) parseTime(value string) (time.Time, error) {
+	if r.layout == "" {
+		if layout, err := smartDetectLayout(value); err == nil {
+			r.layout = layout
+		}
+	}
+	if r.layout != "" {
+		if t, err := time.ParseInLocation(r.layout, value, r.location); err == nil {
+			return t, nil
+		}
+	}
+	return dateparse.ParseIn(value, r.location)
+}
+
+//Time returns the time of the current row
+func (r *CSVReader) Time() time.Time {
+	return r.time
+}
+
+//Values returns the values of the current row, in the order of Names
+func (r *CSVReader) Values() []float64 {
+	return r.values
+}
+
+//Err returns the error which stopped Next, if any
+func (r *CSVReader) Err() error {
+	return r.err
+}
+
+//ReadFrame reads all remaining rows into a Frame
+func (r *CSVReader) ReadFrame() (*Frame, error) {
+	f := &Frame{TimeName: r.timeName, Names: r.names, Columns: map[string][]float64{}}
+	for r.Next() {
+		f.Index = append(f.Index, r.time)
+		for i, name := range r.names {
+			f.Columns[name] = append(f.Columns[name], r.values[i])
+		}
+	}
+	return f, r.Err()
+}
+
+//ReadCSV reads a whole CSV file into a Frame
+func ReadCSV(r io.Reader, opts CSVOptions) (*Frame, error) {
+	reader, err := NewCSVReader(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return reader.ReadFrame()
+}
+
+//WriteCSV writes f as CSV, formatting times with a YYYY-MM-DD hh:mm:ss like layout, RFC3339 if layout is empty
+//NaN values are written as empty fields
+func WriteCSV(w io.Writer, f *Frame, layout string) error {
+	goLayout := time.RFC3339Nano
+	if layout != "" {
+		goLayout = convertLayoutToGolangLayout(layout)
+	}
+	timeName := f.TimeName
+	if timeName == "" {
+		timeName = "time"
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(append([]string{timeName}, f.Names...)); err != nil {
+		return err
+	}
+	record := make([]string, len(f.Names)+1)
+	for row, t := range f.Index {
+		record[0] = t.Format(goLayout)
+		for i, name := range f.Names {
+			column := f.Columns[name]
+			if row >= len(column) {
+				return fmt.Errorf("(WriteCSV) column %q has %v rows, index has %v", name, len(column), len(f.Index))
+			}
+			record[i+1] = ""
+			if !math.IsNaN(column[row]) {
+				record[i+1] = strconv.FormatFloat(column[row], 'f', -1, 64)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
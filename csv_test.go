@@ -0,0 +1,64 @@
+package datetime
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleCSV = `symbol,time,open,close
+A,2020-12-12 09:15:00,1.5,2
+A,2020-12-12 09:17:00,NA,3
+A,2020-12-12 09:20:00,2.5,4
+`
+
+func TestReadCSV(t *testing.T) {
+	parsedTimes, _ := ParseDatetimeArray(times[:3])
+	tests := []struct {
+		name      string
+		input     string
+		opts      CSVOptions
+		wantNames []string
+		wantErr   bool
+	}{
+		{"by name", sampleCSV, CSVOptions{TimeColumn: "time", Columns: []string{"open", "close"}}, []string{"open", "close"}, false},
+		{"by index", "time,open,close\n2020-12-12 09:15:00,1.5,2\n2020-12-12 09:17:00,NA,3\n2020-12-12 09:20:00,2.5,4\n", CSVOptions{TimeColumnIndex: 0}, []string{"open", "close"}, false},
+		{"missing column", sampleCSV, CSVOptions{TimeColumn: "date"}, nil, true},
+		{"non numeric column", sampleCSV, CSVOptions{TimeColumn: "time"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadCSV(strings.NewReader(tt.input), tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadCSV() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got.Index, parsedTimes) || !reflect.DeepEqual(got.Names, tt.wantNames) {
+				t.Errorf("ReadCSV() index = %v, names = %v", got.Index, got.Names)
+			}
+			if open := got.Column("open"); open[0] != 1.5 || !math.IsNaN(open[1]) || open[2] != 2.5 {
+				t.Errorf("ReadCSV() open column = %v", open)
+			}
+		})
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	f, err := ReadCSV(strings.NewReader(sampleCSV), CSVOptions{TimeColumn: "time", Columns: []string{"open", "close"}})
+	if err != nil {
+		t.Fatalf("ReadCSV() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, f, ""); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	want := "time,open,close\n2020-12-12T09:15:00Z,1.5,2\n2020-12-12T09:17:00Z,,3\n2020-12-12T09:20:00Z,2.5,4\n"
+	if buf.String() != want {
+		t.Errorf("WriteCSV() = %q, want %q", buf.String(), want)
+	}
+}
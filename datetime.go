@@ -56,6 +56,7 @@ var matchIntervalWithDigit, _ = regexp.Compile("[0-9]+[a-z]{1}")
 var matchIntervalWithoutDigit, _ = regexp.Compile("^[a-z]")
 
 //ParseInterval parses an interval string like "1minute", "minute", "1m"
+//a year is 365 days
 func ParseInterval(interval string) (time.Duration, error) {
 	match := matchIntervalWithoutDigit.FindString(interval)
 	if match != "" {
@@ -74,7 +75,7 @@ func ParseInterval(interval string) (time.Duration, error) {
 		match = strconv.Itoa(i*24*7) + "h"
 	case 'y':
 		i, _ := strconv.Atoi(match[:len(match)-1])
-		match = strconv.Itoa(i*24*7*365) + "h"
+		match = strconv.Itoa(i*24*365) + "h"
 	}
 	return time.ParseDuration(match)
 }
@@ -93,6 +93,7 @@ func TestParseInterval(t *testing.T) {
 		{"hours", args{"1hour"}, time.Hour, false},
 		{"daynodigit", args{"da"}, time.Hour * 24, false},
 		{"day", args{"1day"}, time.Hour * 24, false},
+		{"year", args{"1y"}, time.Hour * 24 * 365, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
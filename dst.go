@@ -0,0 +1,139 @@
+package datetime
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+//DSTPolicy decides which instant a local clock time means when daylight saving makes it nonexistent or repeated
+type DSTPolicy int
+
+const (
+	//DSTCompatible moves nonexistent times forward by the length of the gap, like 02:30 to 03:30,
+	//and picks the earlier of repeated times
+	DSTCompatible DSTPolicy = iota
+	//DSTEarlier picks the instant before the transition, so nonexistent times move backward by the gap
+	DSTEarlier
+	//DSTLater picks the instant after the transition, so nonexistent times move forward by the gap
+	DSTLater
+	//DSTReject returns an error for nonexistent and repeated times
+	DSTReject
+)
+
+//offsetAt returns the UTC offset of loc in seconds at the instant unix
+func offsetAt(unix int64, loc *time.Location) int64 {
+	_, offset := time.Unix(unix, 0).In(loc).Zone()
+	return int64(offset)
+}
+
+//ResolveLocal returns the instant at which the clocks of loc read the date and clock time of wall, ignoring the location of wall
+//local times skipped or repeated by a daylight saving transition are resolved with policy.
+//with DSTReject an error is returned for them, along with the time DSTCompatible would give
+func ResolveLocal(wall time.Time, loc *time.Location, policy DSTPolicy) (time.Time, error) {
+	asUTC := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, time.UTC).Unix()
+	nsec := time.Duration(wall.Nanosecond())
+	//transitions are far enough apart that the offsets a day either side are the only candidates
+	before, after := offsetAt(asUTC-86400, loc), offsetAt(asUTC+86400, loc)
+	valid := []int64{}
+	for _, offset := range []int64{before, after} {
+		unix := asUTC - offset
+		if offsetAt(unix, loc) == offset && (len(valid) == 0 || valid[0] != unix) {
+			valid = append(valid, unix)
+		}
+	}
+	sort.Slice(valid, func(i, j int) bool { return valid[i] < valid[j] })
+	at := func(unix int64) time.Time { return time.Unix(unix, 0).In(loc).Add(nsec) }
+
+	switch len(valid) {
+	case 1:
+		return at(valid[0]), nil
+	case 0:
+		//a gap, clocks jumped from the before offset to the after offset
+		compatible := at(asUTC - before)
+		switch policy {
+		case DSTEarlier:
+			return at(asUTC - after), nil
+		case DSTReject:
+			return compatible, fmt.Errorf("(ResolveLocal) %v does not exist in %v", wall.Format("2006-01-02 15:04:05"), loc)
+		}
+		return compatible, nil
+	default:
+		switch policy {
+		case DSTLater:
+			return at(valid[1]), nil
+		case DSTReject:
+			return at(valid[0]), fmt.Errorf("(ResolveLocal) %v is ambiguous in %v", wall.Format("2006-01-02 15:04:05"), loc)
+		}
+		return at(valid[0]), nil
+	}
+}
+
+//AddWallClock adds interval to t keeping the local clock time of t for whole days, unlike t.Add
+//so adding 1 day to 09:00 the day before a daylight saving change gives 09:00, 23 or 25 hours later.
+//whole days of interval move the date, resolved in the location of t with policy, see ResolveLocal, and the remainder is added as elapsed time
+//so intervals shorter than a day are plain elapsed time
+func AddWallClock(t time.Time, interval time.Duration, policy DSTPolicy) (time.Time, error) {
+	days := interval / DurationDay()
+	if days == 0 {
+		return t.Add(interval), nil
+	}
+	wall := time.Date(t.Year(), t.Month(), t.Day()+int(days), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	resolved, err := ResolveLocal(wall, t.Location(), policy)
+	return resolved.Add(interval % DurationDay()), err
+}
+
+//Transition is a change of the UTC offset of a location, usually the start or end of daylight saving
+type Transition struct {
+	//At is the first instant with the new offset
+	At           time.Time
+	NameBefore   string
+	OffsetBefore int
+	NameAfter    string
+	OffsetAfter  int
+}
+
+//Shift returns how far clocks moved, positive when they moved forward and local times were skipped
+func (tr Transition) Shift() time.Duration {
+	return time.Duration(tr.OffsetAfter-tr.OffsetBefore) * time.Second
+}
+
+//Transitions finds the changes of UTC offset or zone name of loc in [from, to)
+//it scans in steps of 6 hours, so transitions less than 6 hours apart may be missed
+func Transitions(loc *time.Location, from, to time.Time) []Transition {
+	transitions := []Transition{}
+	const step = 6 * 60 * 60
+	zone := func(unix int64) (string, int) { return time.Unix(unix, 0).In(loc).Zone() }
+	end := to.Unix()
+	if to.Nanosecond() != 0 {
+		end++
+	}
+	for lo := from.Unix(); lo < end; lo += step {
+		hi := lo + step
+		if hi > end {
+			hi = end
+		}
+		nameLo, offsetLo := zone(lo)
+		nameHi, offsetHi := zone(hi)
+		if nameLo == nameHi && offsetLo == offsetHi {
+			continue
+		}
+		//bisect to the first second of the new zone
+		a, b := lo, hi
+		for b-a > 1 {
+			mid := a + (b-a)/2
+			if name, offset := zone(mid); name == nameLo && offset == offsetLo {
+				a = mid
+			} else {
+				b = mid
+			}
+		}
+		at := time.Unix(b, 0).In(loc)
+		if at.Before(from) || !at.Before(to) {
+			continue
+		}
+		nameAfter, offsetAfter := zone(b)
+		transitions = append(transitions, Transition{At: at, NameBefore: nameLo, OffsetBefore: offsetLo, NameAfter: nameAfter, OffsetAfter: offsetAfter})
+	}
+	return transitions
+}
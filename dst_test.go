@@ -0,0 +1,89 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+func newYork(t *testing.T) *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone database not available: %v", err)
+	}
+	return loc
+}
+
+func TestResolveLocal(t *testing.T) {
+	loc := newYork(t)
+	//clocks went from 02:00 EST to 03:00 EDT on 2021-03-14, and from 02:00 EDT back to 01:00 EST on 2021-11-07
+	gap := time.Date(2021, 3, 14, 2, 30, 0, 0, time.UTC)
+	overlap := time.Date(2021, 11, 7, 1, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		wall    time.Time
+		policy  DSTPolicy
+		want    string
+		wantErr bool
+	}{
+		{"unique", time.Date(2021, 3, 14, 9, 0, 0, 0, time.UTC), DSTReject, "2021-03-14T09:00:00-04:00", false},
+		{"gap compatible", gap, DSTCompatible, "2021-03-14T03:30:00-04:00", false},
+		{"gap later", gap, DSTLater, "2021-03-14T03:30:00-04:00", false},
+		{"gap earlier", gap, DSTEarlier, "2021-03-14T01:30:00-05:00", false},
+		{"gap reject", gap, DSTReject, "2021-03-14T03:30:00-04:00", true},
+		{"overlap compatible", overlap, DSTCompatible, "2021-11-07T01:30:00-04:00", false},
+		{"overlap earlier", overlap, DSTEarlier, "2021-11-07T01:30:00-04:00", false},
+		{"overlap later", overlap, DSTLater, "2021-11-07T01:30:00-05:00", false},
+		{"overlap reject", overlap, DSTReject, "2021-11-07T01:30:00-04:00", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveLocal(tt.wall, loc, tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResolveLocal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Format(time.RFC3339) != tt.want {
+				t.Errorf("ResolveLocal() = %v, want %v", got.Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+}
+
+func TestAddWallClock(t *testing.T) {
+	loc := newYork(t)
+	start := time.Date(2021, 3, 13, 9, 0, 0, 0, loc)
+	tests := []struct {
+		name     string
+		interval time.Duration
+		want     string
+	}{
+		{"one day keeps clock", DurationDay(), "2021-03-14T09:00:00-04:00"},
+		{"day and a half", DurationDay() + time.Hour*12, "2021-03-14T21:00:00-04:00"},
+		{"hours are elapsed", time.Hour * 23, "2021-03-14T09:00:00-04:00"},
+		{"backwards", -DurationDay(), "2021-03-12T09:00:00-05:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddWallClock(start, tt.interval, DSTCompatible)
+			if err != nil || got.Format(time.RFC3339) != tt.want {
+				t.Errorf("AddWallClock() = %v, %v, want %v", got.Format(time.RFC3339), err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	loc := newYork(t)
+	got := Transitions(loc, time.Date(2021, 1, 1, 0, 0, 0, 0, loc), time.Date(2022, 1, 1, 0, 0, 0, 0, loc))
+	if len(got) != 2 {
+		t.Fatalf("Transitions() found %v transitions, want 2", len(got))
+	}
+	if want := time.Date(2021, 3, 14, 7, 0, 0, 0, time.UTC); !got[0].At.Equal(want) || got[0].NameBefore != "EST" || got[0].NameAfter != "EDT" || got[0].Shift() != time.Hour {
+		t.Errorf("Transitions()[0] = %+v, want EST to EDT at %v", got[0], want)
+	}
+	if want := time.Date(2021, 11, 7, 6, 0, 0, 0, time.UTC); !got[1].At.Equal(want) || got[1].Shift() != -time.Hour {
+		t.Errorf("Transitions()[1] = %+v, want EDT to EST at %v", got[1], want)
+	}
+	if got := Transitions(time.UTC, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)); len(got) != 0 {
+		t.Errorf("Transitions() in UTC = %v, want none", got)
+	}
+}
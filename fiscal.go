@@ -0,0 +1,158 @@
+package datetime
+
+import "time"
+
+//RetailPattern is the number of weeks in each period of a retail quarter
+type RetailPattern int
+
+const (
+	//CalendarMonths uses plain calendar months as fiscal periods
+	CalendarMonths RetailPattern = iota
+	//Pattern445 splits each quarter into periods of 4, 4 and 5 weeks
+	Pattern445
+	//Pattern454 splits each quarter into periods of 4, 5 and 4 weeks
+	Pattern454
+	//Pattern544 splits each quarter into periods of 5, 4 and 4 weeks
+	Pattern544
+)
+
+var retailPatternWeeks = map[RetailPattern][3]int{
+	Pattern445: {4, 4, 5},
+	Pattern454: {4, 5, 4},
+	Pattern544: {5, 4, 4},
+}
+
+//FiscalCalendar describes a fiscal year starting in StartMonth
+//A fiscal year is named by the calendar year in which it ends, so with StartMonth time.April, FY2021 runs from April 2020 to March 2021
+//With a retail Pattern, years are made of whole weeks starting on WeekStart, and the year starts on the WeekStart nearest to the first of StartMonth
+//giving 52 or 53 week years, the extra week being added to the last period
+type FiscalCalendar struct {
+	StartMonth time.Month
+	Pattern    RetailPattern
+	WeekStart  time.Weekday
+	//Location is where period boundaries fall at midnight, nil means UTC
+	Location *time.Location
+}
+
+//FiscalPeriod is a single period of a fiscal year. Period runs from 1 to 12 and Quarter from 1 to 4
+//End is exclusive, and is the Start of the next period
+type FiscalPeriod struct {
+	Year    int
+	Quarter int
+	Period  int
+	Start   time.Time
+	End     time.Time
+}
+
+//NewFiscalCalendar returns a FiscalCalendar made of calendar months starting in startMonth, in UTC
+func NewFiscalCalendar(startMonth time.Month) FiscalCalendar {
+	return FiscalCalendar{StartMonth: startMonth, Pattern: CalendarMonths, Location: time.UTC}
+}
+
+//NewRetailCalendar returns a 52/53 week FiscalCalendar using pattern, with weeks starting on weekStart, in UTC
+func NewRetailCalendar(startMonth time.Month, pattern RetailPattern, weekStart time.Weekday) FiscalCalendar {
+	return FiscalCalendar{StartMonth: startMonth, Pattern: pattern, WeekStart: weekStart, Location: time.UTC}
+}
+
+func (c FiscalCalendar) location() *time.Location {
+	if c.Location == nil {
+		return time.UTC
+	}
+	return c.Location
+}
+
+func (c FiscalCalendar) startMonth() time.Month {
+	if c.StartMonth < time.January || c.StartMonth > time.December {
+		return time.January
+	}
+	return c.StartMonth
+}
+
+//YearStart returns midnight of the first day of fiscal year `year`
+func (c FiscalCalendar) YearStart(year int) time.Time {
+	calendarYear := year
+	if c.startMonth() != time.January {
+		calendarYear--
+	}
+	nominal := time.Date(calendarYear, c.startMonth(), 1, 0, 0, 0, 0, c.location())
+	if c.Pattern == CalendarMonths {
+		return nominal
+	}
+	back := (int(nominal.Weekday()) - int(c.WeekStart) + 7) % 7
+	if back <= 3 {
+		return nominal.AddDate(0, 0, -back)
+	}
+	return nominal.AddDate(0, 0, 7-back)
+}
+
+//YearEnd returns the start of the fiscal year following `year`, ie the exclusive end of `year`
+func (c FiscalCalendar) YearEnd(year int) time.Time {
+	return c.YearStart(year + 1)
+}
+
+//Year returns the fiscal year containing t
+func (c FiscalCalendar) Year(t time.Time) int {
+	t = t.In(c.location())
+	year := t.Year()
+	for !t.Before(c.YearStart(year + 1)) {
+		year++
+	}
+	for t.Before(c.YearStart(year)) {
+		year--
+	}
+	return year
+}
+
+//Periods returns all 12 periods of fiscal year `year` in order
+func (c FiscalCalendar) Periods(year int) []FiscalPeriod {
+	periods := make([]FiscalPeriod, 0, 12)
+	start := c.YearStart(year)
+	end := c.YearEnd(year)
+	for i := 0; i < 12; i++ {
+		var next time.Time
+		switch {
+		case i == 11:
+			next = end
+		case c.Pattern == CalendarMonths:
+			next = start.AddDate(0, 1, 0)
+		default:
+			next = start.AddDate(0, 0, 7*retailPatternWeeks[c.Pattern][i%3])
+		}
+		periods = append(periods, FiscalPeriod{Year: year, Quarter: i/3 + 1, Period: i + 1, Start: start, End: next})
+		start = next
+	}
+	return periods
+}
+
+//Period returns the fiscal period containing t
+func (c FiscalCalendar) Period(t time.Time) FiscalPeriod {
+	periods := c.Periods(c.Year(t))
+	for _, p := range periods {
+		if DatetimeIsInRange(t, p.Start, p.End) {
+			return p
+		}
+	}
+	return periods[len(periods)-1]
+}
+
+//Quarter returns the fiscal year and fiscal quarter containing t
+func (c FiscalCalendar) Quarter(t time.Time) (year, quarter int) {
+	p := c.Period(t)
+	return p.Year, p.Quarter
+}
+
+//PeriodsBetween returns every fiscal period overlapping [t1, t2) in order
+func (c FiscalCalendar) PeriodsBetween(t1, t2 time.Time) []FiscalPeriod {
+	periods := []FiscalPeriod{}
+	if !t1.Before(t2) {
+		return periods
+	}
+	for year := c.Year(t1); year <= c.Year(t2); year++ {
+		for _, p := range c.Periods(year) {
+			if p.End.After(t1) && p.Start.Before(t2) {
+				periods = append(periods, p)
+			}
+		}
+	}
+	return periods
+}
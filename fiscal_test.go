@@ -0,0 +1,41 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFiscalCalendarPeriod(t *testing.T) {
+	april := NewFiscalCalendar(time.April)
+	nrf := NewRetailCalendar(time.February, Pattern445, time.Sunday)
+	tests := []struct {
+		name     string
+		calendar FiscalCalendar
+		t        time.Time
+		want     FiscalPeriod
+	}{
+		{"april start, first period", april, time.Date(2020, 4, 10, 0, 0, 0, 0, time.UTC), FiscalPeriod{2021, 1, 1, time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)}},
+		{"april start, last period", april, time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC), FiscalPeriod{2021, 4, 12, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)}},
+		{"january start", NewFiscalCalendar(time.January), time.Date(2021, 5, 5, 0, 0, 0, 0, time.UTC), FiscalPeriod{2021, 2, 5, time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)}},
+		{"445 third period is 5 weeks", nrf, time.Date(2021, 4, 10, 0, 0, 0, 0, time.UTC), FiscalPeriod{2022, 1, 3, time.Date(2021, 3, 28, 0, 0, 0, 0, time.UTC), time.Date(2021, 5, 2, 0, 0, 0, 0, time.UTC)}},
+		{"445 53 week year", nrf, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), FiscalPeriod{2024, 4, 12, time.Date(2023, 12, 24, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.calendar.Period(tt.t); got != tt.want {
+				t.Errorf("FiscalCalendar.Period() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFiscalCalendarPeriodsBetween(t *testing.T) {
+	c := NewFiscalCalendar(time.July)
+	got := c.PeriodsBetween(time.Date(2021, 6, 15, 0, 0, 0, 0, time.UTC), time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC))
+	if len(got) != 2 {
+		t.Fatalf("FiscalCalendar.PeriodsBetween() returned %v periods, want 2", len(got))
+	}
+	if got[0].Year != 2021 || got[0].Period != 12 || got[1].Year != 2022 || got[1].Period != 1 {
+		t.Errorf("FiscalCalendar.PeriodsBetween() = %+v", got)
+	}
+}
@@ -0,0 +1,136 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/araddon/dateparse"
+)
+
+//FlexTime is a time.Time which unmarshals from any format ParseDatetime handles, rather than only RFC3339
+//so structs can decode messy payloads directly. Parsing and formatting follow the FlexTimeConfig the value was created with, see FlexTimeConfig.FlexTime,
+//and the zero FlexTimeConfig otherwise, so packages using FlexTime do not change each other's settings
+//It implements json.Marshaler, encoding.TextMarshaler (also used by YAML decoders), sql.Scanner and driver.Valuer. The zero time marshals to null in JSON
+type FlexTime struct {
+	time.Time
+	config *FlexTimeConfig
+}
+
+//FlexTimeConfig decides how FlexTime values are parsed and formatted
+type FlexTimeConfig struct {
+	//Location is used for times without a timezone, nil means UTC
+	Location *time.Location
+	//Layouts are YYYY-MM-DD hh:mm:ss like layouts tried in order before ParseDatetime, for formats it gets wrong like DD/MM/YYYY
+	Layouts []string
+	//OutputLayout is a YYYY-MM-DD hh:mm:ss like layout used for marshaling, RFC3339 if empty
+	OutputLayout string
+}
+
+//FlexTime creates a FlexTime parsed and formatted with a copy of this config, so later changes to the config do not affect it
+//to decode with the config, set the fields of the target first, like payload{At: config.FlexTime(time.Time{})}, as decoding keeps the config of a value
+func (c FlexTimeConfig) FlexTime(t time.Time) FlexTime {
+	c.Layouts = append([]string{}, c.Layouts...)
+	return FlexTime{Time: t, config: &c}
+}
+
+//Config returns the FlexTimeConfig used by this value
+func (t FlexTime) Config() FlexTimeConfig {
+	if t.config == nil {
+		return FlexTimeConfig{}
+	}
+	return *t.config
+}
+
+//Parse parses datetime with the configured layouts, falling back to the formats ParseDatetime detects
+//datetimes without a zone are read in the configured location, UTC if nil
+func (c FlexTimeConfig) Parse(datetime string) (time.Time, error) {
+	loc := c.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	for _, layout := range c.Layouts {
+		if t, err := time.ParseInLocation(convertLayoutToGolangLayout(layout), datetime, loc); err == nil {
+			return t, nil
+		}
+	}
+	return dateparse.ParseIn(datetime, loc)
+}
+
+//Format formats t with the configured output layout
+func (c FlexTimeConfig) Format(t time.Time) string {
+	if c.OutputLayout == "" {
+		return t.Format(time.RFC3339Nano)
+	}
+	return t.Format(convertLayoutToGolangLayout(c.OutputLayout))
+}
+
+//MarshalText implements encoding.TextMarshaler
+func (t FlexTime) MarshalText() ([]byte, error) {
+	return []byte(t.Config().Format(t.Time)), nil
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler
+func (t *FlexTime) UnmarshalText(text []byte) error {
+	parsed, err := t.Config().Parse(string(text))
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+//MarshalJSON implements json.Marshaler
+func (t FlexTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	text, _ := t.MarshalText()
+	return json.Marshal(string(text))
+}
+
+//UnmarshalJSON implements json.Unmarshaler. Numbers are read as epoch timestamps, in seconds, milliseconds, microseconds or nanoseconds by their length
+func (t *FlexTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return t.UnmarshalText([]byte(s))
+	}
+	var epoch json.Number
+	if err := json.Unmarshal(data, &epoch); err != nil {
+		return fmt.Errorf("(FlexTime) cannot unmarshal %s: must be a string or an epoch timestamp", data)
+	}
+	return t.UnmarshalText([]byte(epoch.String()))
+}
+
+//Scan implements sql.Scanner, accepting times, strings and epoch timestamps
+func (t *FlexTime) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		t.Time = time.Time{}
+		return nil
+	case time.Time:
+		t.Time = src
+		return nil
+	case string:
+		return t.UnmarshalText([]byte(src))
+	case []byte:
+		return t.UnmarshalText(src)
+	case int64:
+		return t.UnmarshalText([]byte(strconv.FormatInt(src, 10)))
+	default:
+		return fmt.Errorf("(FlexTime) cannot scan %v of type %T", src, src)
+	}
+}
+
+//Value implements driver.Valuer, storing the zero time as NULL
+func (t FlexTime) Value() (driver.Value, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+	return t.Time, nil
+}
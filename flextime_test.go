@@ -0,0 +1,78 @@
+package datetime
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestFlexTimeJSON(t *testing.T) {
+	type payload struct {
+		At FlexTime `json:"at"`
+	}
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"rfc3339", `{"at": "2020-12-12T09:15:00Z"}`, time.Date(2020, 12, 12, 9, 15, 0, 0, time.UTC), false},
+		{"space separated", `{"at": "2020-12-12 09:15"}`, time.Date(2020, 12, 12, 9, 15, 0, 0, time.UTC), false},
+		{"epoch ms", `{"at": 1607764500000}`, time.Date(2020, 12, 12, 9, 15, 0, 0, time.UTC), false},
+		{"null", `{"at": null}`, time.Time{}, false},
+		{"invalid", `{"at": "20 12-12"}`, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p payload
+			err := json.Unmarshal([]byte(tt.input), &p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("json.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !p.At.Equal(tt.want) {
+				t.Errorf("json.Unmarshal() = %v, want %v", p.At, tt.want)
+			}
+		})
+	}
+	out, _ := json.Marshal(payload{FlexTime{Time: time.Date(2020, 12, 12, 9, 15, 0, 0, time.UTC)}})
+	if string(out) != `{"at":"2020-12-12T09:15:00Z"}` {
+		t.Errorf("json.Marshal() = %s", out)
+	}
+}
+
+func TestFlexTimeConfig(t *testing.T) {
+	ist := time.FixedZone("IST", 19800)
+	config := FlexTimeConfig{Location: ist, Layouts: []string{"DD/MM/YYYY hh:mm"}, OutputLayout: "YYYY-MM-DD hh:mm"}
+
+	type payload struct {
+		At FlexTime `json:"at"`
+	}
+	p := payload{At: config.FlexTime(time.Time{})}
+	if err := json.Unmarshal([]byte(`{"at": "02/01/2021 09:15"}`), &p); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if want := time.Date(2021, 1, 2, 9, 15, 0, 0, ist); !p.At.Equal(want) {
+		t.Errorf("json.Unmarshal() with layouts = %v, want %v", p.At, want)
+	}
+	got := p.At
+	if err := json.Unmarshal([]byte(`"2021-01-02 09:15"`), &got); err != nil || !got.Equal(time.Date(2021, 1, 2, 9, 15, 0, 0, ist)) {
+		t.Errorf("json.Unmarshal() with fallback = %v, %v", got, err)
+	}
+	if out, _ := json.Marshal(got); string(out) != `"2021-01-02 09:15"` {
+		t.Errorf("json.Marshal() with output layout = %s", out)
+	}
+
+	//changing the config afterwards, or using FlexTime elsewhere, does not affect the value
+	config.Layouts[0], config.OutputLayout = "MM/DD/YYYY hh:mm", ""
+	if out, _ := json.Marshal(got); string(out) != `"2021-01-02 09:15"` {
+		t.Errorf("json.Marshal() after config change = %s", out)
+	}
+	var plain FlexTime
+	if err := json.Unmarshal([]byte(`"2021-01-02 09:15"`), &plain); err != nil || !plain.Equal(time.Date(2021, 1, 2, 9, 15, 0, 0, time.UTC)) {
+		t.Errorf("json.Unmarshal() without config = %v, %v", plain, err)
+	}
+	if out, _ := json.Marshal(plain); string(out) != `"2021-01-02T09:15:00Z"` {
+		t.Errorf("json.Marshal() without config = %s", out)
+	}
+}
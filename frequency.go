@@ -0,0 +1,240 @@
+package datetime
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+//Diffs returns the spacing between consecutive times of index, so Diffs(index)[i] is index[i+1] - index[i]
+func Diffs(index []time.Time) []time.Duration {
+	if len(index) < 2 {
+		return []time.Duration{}
+	}
+	diffs := make([]time.Duration, len(index)-1)
+	for i := range diffs {
+		diffs[i] = index[i+1].Sub(index[i])
+	}
+	return diffs
+}
+
+//Percentile returns the p-th percentile of diffs, p in [0, 100], interpolating linearly between the two nearest values
+//diffs need not be sorted. 0 is returned if diffs is empty
+func Percentile(diffs []time.Duration, p float64) time.Duration {
+	if len(diffs) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration{}, diffs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sortedPercentile(sorted, p)
+}
+
+func sortedPercentile(sorted []time.Duration, p float64) time.Duration {
+	p = math.Max(0, math.Min(100, p))
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	if lower == len(sorted)-1 {
+		return sorted[lower]
+	}
+	return sorted[lower] + time.Duration(math.Round(float64(sorted[lower+1]-sorted[lower])*(rank-float64(lower))))
+}
+
+//SpacingStats summarizes the spacing between consecutive times of an index
+type SpacingStats struct {
+	//Count is the number of spacings, one less than the length of the index
+	Count  int
+	Min    time.Duration
+	Max    time.Duration
+	Mean   time.Duration
+	Median time.Duration
+	//Mode is the most common spacing, the smallest one on ties
+	Mode time.Duration
+	//Irregular is the number of spacings different from Mode
+	Irregular int
+	sorted    []time.Duration
+}
+
+//Percentile returns the p-th percentile of the spacings, see Percentile
+func (s SpacingStats) Percentile(p float64) time.Duration {
+	if len(s.sorted) == 0 {
+		return 0
+	}
+	return sortedPercentile(s.sorted, p)
+}
+
+//DescribeSpacing summarizes the spacing of index, which must be sorted and have at least 2 times
+func DescribeSpacing(index []time.Time) (SpacingStats, error) {
+	if len(index) < 2 {
+		return SpacingStats{}, fmt.Errorf("(DescribeSpacing) need at least 2 times, got %v", len(index))
+	}
+	if err := checkSorted("DescribeSpacing", "index", index); err != nil {
+		return SpacingStats{}, err
+	}
+	sorted := Diffs(index)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	s := SpacingStats{Count: len(sorted), Min: sorted[0], Max: sorted[len(sorted)-1], sorted: sorted}
+	s.Median = sortedPercentile(sorted, 50)
+	s.Mean = index[len(index)-1].Sub(index[0]) / time.Duration(len(sorted))
+
+	//sorted, so equal spacings are runs, and the first longest run is the smallest mode
+	best, run := 0, 0
+	for i := range sorted {
+		if i != 0 && sorted[i] == sorted[i-1] {
+			run++
+		} else {
+			run = 1
+		}
+		if run > best {
+			best, s.Mode = run, sorted[i]
+		}
+	}
+	s.Irregular = s.Count - best
+	return s, nil
+}
+
+//FrequencyKind is the kind of spacing found by InferFrequency
+type FrequencyKind int
+
+const (
+	//FrequencyFixed is a fixed spacing, Frequency.Interval
+	FrequencyFixed FrequencyKind = iota
+	//FrequencyBusinessDay is every Monday to Friday
+	FrequencyBusinessDay
+	FrequencyMonthStart
+	FrequencyMonthEnd
+	FrequencyQuarterStart
+	FrequencyQuarterEnd
+	FrequencyYearStart
+	FrequencyYearEnd
+)
+
+var frequencyKindNames = map[FrequencyKind]string{
+	FrequencyBusinessDay:  "business day",
+	FrequencyMonthStart:   "month start",
+	FrequencyMonthEnd:     "month end",
+	FrequencyQuarterStart: "quarter start",
+	FrequencyQuarterEnd:   "quarter end",
+	FrequencyYearStart:    "year start",
+	FrequencyYearEnd:      "year end",
+}
+
+//Frequency is the natural spacing of an index, see InferFrequency
+type Frequency struct {
+	Kind FrequencyKind
+	//Interval is the spacing of a FrequencyFixed frequency, 0 for the calendar kinds
+	Interval time.Duration
+	//Share is the fraction of spacings which follow the frequency, 1 for the calendar kinds
+	Share float64
+}
+
+//String names the frequency, like "5m" using the Interval format, or "month end"
+func (f Frequency) String() string {
+	if f.Kind == FrequencyFixed {
+		return Interval(f.Interval).String()
+	}
+	return frequencyKindNames[f.Kind]
+}
+
+//calendarDays returns the number of dates from t1 to t2, each read in its own location, so daylight saving does not matter
+func calendarDays(t1, t2 time.Time) int {
+	d1 := time.Date(t1.Year(), t1.Month(), t1.Day(), 0, 0, 0, 0, time.UTC)
+	d2 := time.Date(t2.Year(), t2.Month(), t2.Day(), 0, 0, 0, 0, time.UTC)
+	return int(d2.Sub(d1) / DurationDay())
+}
+
+//calendarMonths returns the number of months from t1 to t2
+func calendarMonths(t1, t2 time.Time) int {
+	return (t2.Year()-t1.Year())*12 + int(t2.Month()) - int(t1.Month())
+}
+
+func isMonthEnd(t time.Time) bool {
+	return t.AddDate(0, 0, 1).Day() == 1
+}
+
+//inferCalendarFrequency checks index against the calendar frequencies, which have uneven spacing
+//every time must be at the same clock time and follow the frequency exactly
+func inferCalendarFrequency(index []time.Time) (FrequencyKind, bool) {
+	for i := 1; i < len(index); i++ {
+		if !TimeIsEqual(index[i], index[0], true) {
+			return 0, false
+		}
+	}
+	calendar := []struct {
+		kind   FrequencyKind
+		months int
+		onDate func(time.Time) bool
+	}{
+		{FrequencyMonthStart, 1, func(t time.Time) bool { return t.Day() == 1 }},
+		{FrequencyMonthEnd, 1, isMonthEnd},
+		{FrequencyQuarterStart, 3, func(t time.Time) bool { return t.Day() == 1 && t.Month()%3 == 1 }},
+		{FrequencyQuarterEnd, 3, func(t time.Time) bool { return isMonthEnd(t) && t.Month()%3 == 0 }},
+		{FrequencyYearStart, 12, func(t time.Time) bool { return t.Day() == 1 && t.Month() == time.January }},
+		{FrequencyYearEnd, 12, func(t time.Time) bool { return isMonthEnd(t) && t.Month() == time.December }},
+	}
+	for _, c := range calendar {
+		matched := true
+		for i := range index {
+			if !c.onDate(index[i]) || (i != 0 && calendarMonths(index[i-1], index[i]) != c.months) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return c.kind, true
+		}
+	}
+
+	//business days step one day, or over the weekend from Friday to Monday, which must happen at least once
+	weekends := 0
+	for i := range index {
+		if weekday := index[i].Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+			return 0, false
+		}
+		if i == 0 {
+			continue
+		}
+		switch days := calendarDays(index[i-1], index[i]); {
+		case days == 3 && index[i].Weekday() == time.Monday:
+			weekends++
+		case days != 1:
+			return 0, false
+		}
+	}
+	return FrequencyBusinessDay, weekends != 0
+}
+
+//InferFrequency finds the natural spacing of index, so a resampling interval can be picked automatically
+//calendar frequencies like business days or month ends are detected when every time follows them,
+//otherwise the most common spacing is returned, with Share telling how regular the index is
+//index must be sorted and have at least 2 distinct times
+func InferFrequency(index []time.Time) (Frequency, error) {
+	stats, err := DescribeSpacing(index)
+	if err != nil {
+		return Frequency{}, err
+	}
+	if stats.Max == 0 {
+		return Frequency{}, fmt.Errorf("(InferFrequency) index has no distinct times")
+	}
+	if kind, ok := inferCalendarFrequency(index); ok {
+		return Frequency{Kind: kind, Share: 1}, nil
+	}
+	//a duplicated time is not a frequency, so the most common positive spacing is used
+	mode, share := stats.Mode, float64(stats.Count-stats.Irregular)/float64(stats.Count)
+	if mode == 0 {
+		counts := map[time.Duration]int{}
+		for _, d := range stats.sorted {
+			if d > 0 {
+				counts[d]++
+			}
+		}
+		best := 0
+		for _, d := range stats.sorted {
+			if counts[d] > best {
+				mode, best = d, counts[d]
+			}
+		}
+		share = float64(best) / float64(stats.Count)
+	}
+	return Frequency{Kind: FrequencyFixed, Interval: mode, Share: share}, nil
+}
@@ -0,0 +1,109 @@
+package datetime
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffs(t *testing.T) {
+	base := time.Date(2021, 3, 4, 9, 0, 0, 0, time.UTC)
+	index := []time.Time{base, base.Add(time.Minute), base.Add(time.Minute * 3)}
+	if got, want := Diffs(index), []time.Duration{time.Minute, time.Minute * 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Diffs() = %v, want %v", got, want)
+	}
+	if got := Diffs(index[:1]); len(got) != 0 {
+		t.Errorf("Diffs() of one time = %v, want empty", got)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	diffs := []time.Duration{time.Second * 4, time.Second, time.Second * 3, time.Second * 2}
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, time.Second},
+		{50, time.Millisecond * 2500},
+		{100, time.Second * 4},
+		{150, time.Second * 4},
+	}
+	for _, tt := range tests {
+		if got := Percentile(diffs, tt.p); got != tt.want {
+			t.Errorf("Percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := Percentile(nil, 50); got != 0 {
+		t.Errorf("Percentile() of nothing = %v, want 0", got)
+	}
+}
+
+func TestDescribeSpacing(t *testing.T) {
+	base := time.Date(2021, 3, 4, 9, 0, 0, 0, time.UTC)
+	index := append(GenerateTimeRange(base, time.Minute, 10), base.Add(time.Minute*15), base.Add(time.Minute*16))
+	got, err := DescribeSpacing(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Count != 11 || got.Min != time.Minute || got.Max != time.Minute*6 || got.Median != time.Minute || got.Mode != time.Minute || got.Irregular != 1 {
+		t.Errorf("DescribeSpacing() = %+v", got)
+	}
+	if got.Mean != time.Minute*16/11 {
+		t.Errorf("DescribeSpacing() mean = %v, want %v", got.Mean, time.Minute*16/11)
+	}
+	if p := got.Percentile(100); p != time.Minute*6 {
+		t.Errorf("SpacingStats.Percentile(100) = %v, want 6m", p)
+	}
+	if _, err := DescribeSpacing([]time.Time{base, base.Add(-time.Minute)}); err == nil {
+		t.Errorf("DescribeSpacing() of unsorted index should fail")
+	}
+}
+
+func TestInferFrequency(t *testing.T) {
+	base := time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)
+	businessDays := []time.Time{}
+	for d := base; len(businessDays) < 10; d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			businessDays = append(businessDays, d)
+		}
+	}
+	monthEnds, monthStarts, quarterEnds := []time.Time{}, []time.Time{}, []time.Time{}
+	for m := 1; m <= 12; m++ {
+		monthStarts = append(monthStarts, time.Date(2021, time.Month(m), 1, 0, 0, 0, 0, time.UTC))
+		monthEnds = append(monthEnds, time.Date(2021, time.Month(m)+1, 0, 0, 0, 0, 0, time.UTC))
+		if m%3 == 0 {
+			quarterEnds = append(quarterEnds, time.Date(2021, time.Month(m)+1, 0, 0, 0, 0, 0, time.UTC))
+		}
+	}
+	gappy := append(GenerateTimeRange(base, time.Minute*5, 6), base.Add(time.Hour))
+	tests := []struct {
+		name      string
+		index     []time.Time
+		want      string
+		wantShare float64
+		wantErr   bool
+	}{
+		{"seconds", GenerateTimeRange(base, time.Second, 10), "1s", 1, false},
+		{"5 minutes with a gap", gappy, "5m", float64(5) / 6, false},
+		{"daily", GenerateTimeRange(base, DurationDay(), 10), "1d", 1, false},
+		{"business days", businessDays, "business day", 1, false},
+		{"month starts", monthStarts, "month start", 1, false},
+		{"month ends", monthEnds, "month end", 1, false},
+		{"quarter ends", quarterEnds, "quarter end", 1, false},
+		{"duplicates", []time.Time{base, base, base.Add(time.Hour), base.Add(time.Hour), base.Add(time.Hour * 2)}, "1h", 0.5, false},
+		{"too short", []time.Time{base}, "", 0, true},
+		{"no distinct times", []time.Time{base, base}, "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InferFrequency(tt.index)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InferFrequency() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && (got.String() != tt.want || got.Share != tt.wantShare) {
+				t.Errorf("InferFrequency() = %v (%v), want %v (%v)", got, got.Share, tt.want, tt.wantShare)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package datetime
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+//KeyFunc maps a time to the key of the group it belongs to
+type KeyFunc func(time.Time) int
+
+//Group holds the positions in an index of all samples sharing Key
+type Group struct {
+	Key       int
+	Positions []int
+}
+
+//KeyHourOfDay is a KeyFunc grouping by hour field, from 0 to 23
+func KeyHourOfDay(t time.Time) int {
+	return t.Hour()
+}
+
+//KeyTimeOfDay is a KeyFunc grouping by hour, minute and second fields, as seconds since midnight
+func KeyTimeOfDay(t time.Time) int {
+	return t.Hour()*3600 + t.Minute()*60 + t.Second()
+}
+
+//KeyWeekday is a KeyFunc grouping by day of week, from 0 for Sunday to 6 for Saturday
+func KeyWeekday(t time.Time) int {
+	return int(t.Weekday())
+}
+
+//KeyDayOfMonth is a KeyFunc grouping by day field, from 1 to 31
+func KeyDayOfMonth(t time.Time) int {
+	return t.Day()
+}
+
+//KeyMonth is a KeyFunc grouping by month field, from 1 to 12
+func KeyMonth(t time.Time) int {
+	return int(t.Month())
+}
+
+//KeyQuarter is a KeyFunc grouping by calendar quarter, from 1 to 4, see Quarter
+func KeyQuarter(t time.Time) int {
+	return Quarter(t)
+}
+
+//KeyYear is a KeyFunc grouping by year field
+func KeyYear(t time.Time) int {
+	return t.Year()
+}
+
+//KeyDateOnly is a KeyFunc grouping by date, as the integer yyyymmdd
+func KeyDateOnly(t time.Time) int {
+	return t.Year()*10000 + int(t.Month())*100 + t.Day()
+}
+
+//GroupBy groups the positions of index by the key of each time, unlike BucketTimeArrayByInterval samples need not be contiguous
+//so GroupBy(index, KeyHourOfDay) collects all samples at 10:xx across days in a single group
+//groups are ordered by key, and positions within a group keep the order of index
+func GroupBy(index []time.Time, key KeyFunc) []Group {
+	groupOf := map[int]int{}
+	groups := []Group{}
+	for i, t := range index {
+		k := key(t)
+		g, exists := groupOf[k]
+		if !exists {
+			g = len(groups)
+			groupOf[k] = g
+			groups = append(groups, Group{Key: k})
+		}
+		groups[g].Positions = append(groups[g].Positions, i)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Key < groups[j].Key })
+	return groups
+}
+
+//Times returns the times of index in this group
+func (g Group) Times(index []time.Time) []time.Time {
+	grouped := make([]time.Time, len(g.Positions))
+	for i, p := range g.Positions {
+		grouped[i] = index[p]
+	}
+	return grouped
+}
+
+//Float64s returns the values of data in this group
+func (g Group) Float64s(data []float64) []float64 {
+	grouped := make([]float64, len(g.Positions))
+	for i, p := range g.Positions {
+		grouped[i] = data[p]
+	}
+	return grouped
+}
+
+//GroupDataSliceByGroups partitions data of any slice type by the positions in groups
+//After recieving output, convert to to type using  output.([][]Typename)
+func GroupDataSliceByGroups(groups []Group, data interface{}) (interface{}, error) {
+	dataType := reflect.TypeOf(data)
+	dataValue := reflect.ValueOf(data)
+	if dataType == nil || dataType.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("(GroupDataSliceByGroups) failed because data is not a slice type")
+	}
+	groupedData := reflect.MakeSlice(reflect.SliceOf(dataType), 0, len(groups))
+	for _, g := range groups {
+		group := reflect.MakeSlice(dataType, 0, len(g.Positions))
+		for _, p := range g.Positions {
+			if p >= dataValue.Len() {
+				return groupedData.Interface(), fmt.Errorf("(GroupDataSliceByGroups) position %v out of range for data of length %v", p, dataValue.Len())
+			}
+			group = reflect.Append(group, dataValue.Index(p))
+		}
+		groupedData = reflect.Append(groupedData, group)
+	}
+	return groupedData.Interface(), nil
+}
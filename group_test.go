@@ -0,0 +1,44 @@
+package datetime
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	parsedTimes, _ := ParseDatetimeArray([]string{"2021-03-01 10:00:00", "2021-03-01 11:00:00", "2021-03-02 10:00:00", "2021-03-08 09:00:00"})
+	tests := []struct {
+		name string
+		key  KeyFunc
+		want []Group
+	}{
+		{"hour of day", KeyHourOfDay, []Group{{9, []int{3}}, {10, []int{0, 2}}, {11, []int{1}}}},
+		{"weekday", KeyWeekday, []Group{{1, []int{0, 1, 3}}, {2, []int{2}}}},
+		{"date only", KeyDateOnly, []Group{{20210301, []int{0, 1}}, {20210302, []int{2}}, {20210308, []int{3}}}},
+		{"quarter", KeyQuarter, []Group{{1, []int{0, 1, 2, 3}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GroupBy(parsedTimes, tt.key); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GroupBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupDataSliceByGroups(t *testing.T) {
+	groups := []Group{{9, []int{3}}, {10, []int{0, 2}}, {11, []int{1}}}
+	got, err := GroupDataSliceByGroups(groups, []string{"a", "b", "c", "d"})
+	if err != nil {
+		t.Fatalf("GroupDataSliceByGroups() error = %v", err)
+	}
+	if want := [][]string{{"d"}, {"a", "c"}, {"b"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupDataSliceByGroups() = %v, want %v", got, want)
+	}
+	if _, err := GroupDataSliceByGroups(groups, []string{"a"}); err == nil {
+		t.Errorf("GroupDataSliceByGroups() expected error for short data")
+	}
+	if got := groups[1].Float64s([]float64{0, 1, 2, 3}); !reflect.DeepEqual(got, []float64{0, 2}) {
+		t.Errorf("Group.Float64s() = %v", got)
+	}
+}
@@ -0,0 +1,199 @@
+package datetime
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+//Unit is a unit used to describe durations to humans. Months are 30 days and years 365 days
+type Unit int
+
+//Units from smallest to largest
+const (
+	UnitSecond Unit = iota
+	UnitMinute
+	UnitHour
+	UnitDay
+	UnitWeek
+	UnitMonth
+	UnitYear
+)
+
+var unitDurations = map[Unit]time.Duration{
+	UnitSecond: time.Second,
+	UnitMinute: time.Minute,
+	UnitHour:   time.Hour,
+	UnitDay:    DurationDay(),
+	UnitWeek:   DurationWeek(),
+	UnitMonth:  DurationDay() * 30,
+	UnitYear:   DurationDay() * 365,
+}
+
+//Duration returns the length of the unit
+func (u Unit) Duration() time.Duration {
+	return unitDurations[u]
+}
+
+//HumanizeLocale produces the words of Humanize and RelativeTo, so they can be localized
+type HumanizeLocale interface {
+	//Unit returns n units, like "3 weeks"
+	Unit(u Unit, n int) string
+	//List joins units, like "2 hours 5 minutes"
+	List(parts []string) string
+	//About marks an approximate duration, like "about 3 weeks"
+	About(duration string) string
+	//Ago describes a duration in the past, like "3 days ago"
+	Ago(duration string) string
+	//In describes a duration in the future, like "in 2 hours"
+	In(duration string) string
+	//Now describes a time too close to tell apart, like "just now"
+	Now() string
+	//Yesterday describes a time on the previous day, like "yesterday at 09:15"
+	Yesterday(t time.Time) string
+	//Tomorrow describes a time on the next day, like "tomorrow at 09:15"
+	Tomorrow(t time.Time) string
+}
+
+type englishHumanizer struct{}
+
+var englishUnitNames = map[Unit]string{
+	UnitSecond: "second",
+	UnitMinute: "minute",
+	UnitHour:   "hour",
+	UnitDay:    "day",
+	UnitWeek:   "week",
+	UnitMonth:  "month",
+	UnitYear:   "year",
+}
+
+func (englishHumanizer) Unit(u Unit, n int) string {
+	if n == 1 {
+		return "1 " + englishUnitNames[u]
+	}
+	return fmt.Sprintf("%v %vs", n, englishUnitNames[u])
+}
+func (englishHumanizer) List(parts []string) string   { return strings.Join(parts, " ") }
+func (englishHumanizer) About(duration string) string { return "about " + duration }
+func (englishHumanizer) Ago(duration string) string   { return duration + " ago" }
+func (englishHumanizer) In(duration string) string    { return "in " + duration }
+func (englishHumanizer) Now() string                  { return "just now" }
+func (englishHumanizer) Yesterday(t time.Time) string { return "yesterday at " + t.Format("15:04") }
+func (englishHumanizer) Tomorrow(t time.Time) string  { return "tomorrow at " + t.Format("15:04") }
+
+//HumanizeEnglish is the default HumanizeLocale
+var HumanizeEnglish HumanizeLocale = englishHumanizer{}
+
+//HumanizeOptions configures Humanize and RelativeTo
+type HumanizeOptions struct {
+	//Precision is the maximum number of units used, 2 for Humanize and 1 for RelativeTo if not positive
+	//smaller units are dropped rather than rounded
+	Precision int
+	//Units are the units which may be used, every unit if nil. Values other than UnitSecond to UnitYear are ignored
+	Units []Unit
+	//Approximate rounds to the largest unit, marking it as approximate if anything was rounded, like "about 3 weeks"
+	Approximate bool
+	//Locale produces the words, HumanizeEnglish if nil
+	Locale HumanizeLocale
+}
+
+func (o HumanizeOptions) withDefaults(precision int) HumanizeOptions {
+	if o.Precision <= 0 {
+		o.Precision = precision
+	}
+	known := []Unit{}
+	for _, u := range o.Units {
+		if u.Duration() != 0 {
+			known = append(known, u)
+		}
+	}
+	o.Units = known
+	if len(o.Units) == 0 {
+		o.Units = []Unit{UnitYear, UnitMonth, UnitWeek, UnitDay, UnitHour, UnitMinute, UnitSecond}
+	}
+	if o.Locale == nil {
+		o.Locale = HumanizeEnglish
+	}
+	return o
+}
+
+//humanize describes the absolute value of d, and reports if it was shorter than the smallest unit
+func (o HumanizeOptions) humanize(d time.Duration) (string, bool) {
+	if d < 0 {
+		d = -d
+	}
+	units := append([]Unit{}, o.Units...)
+	sort.Slice(units, func(i, j int) bool { return units[i].Duration() > units[j].Duration() })
+	smallest := units[len(units)-1]
+	if d < smallest.Duration() {
+		return o.Locale.Unit(smallest, 0), true
+	}
+
+	if o.Approximate {
+		for _, u := range units {
+			if d >= u.Duration() {
+				n := int((d + u.Duration()/2) / u.Duration())
+				described := o.Locale.Unit(u, n)
+				if d%u.Duration() != 0 {
+					described = o.Locale.About(described)
+				}
+				return described, false
+			}
+		}
+	}
+
+	parts := []string{}
+	for _, u := range units {
+		if len(parts) == o.Precision {
+			break
+		}
+		if n := d / u.Duration(); n > 0 {
+			parts = append(parts, o.Locale.Unit(u, int(n)))
+			d -= n * u.Duration()
+		}
+	}
+	return o.Locale.List(parts), false
+}
+
+//Humanize describes a duration in words, like "2 hours 5 minutes", the reverse of ParseInterval
+//by default at most 2 units are used. The sign of d is ignored, see RelativeTo for past and future
+func Humanize(d time.Duration, opts ...HumanizeOptions) string {
+	var opt HumanizeOptions
+	if opts != nil {
+		opt = opts[0]
+	}
+	described, _ := opt.withDefaults(2).humanize(d)
+	return described
+}
+
+//RelativeTo describes t relative to ref, like "3 days ago", "in 2 hours" or "yesterday at 09:15"
+//times on the day before or after ref which are more than an hour away are described by their clock time
+//by default only the largest unit is used
+func RelativeTo(t, ref time.Time, opts ...HumanizeOptions) string {
+	var opt HumanizeOptions
+	if opts != nil {
+		opt = opts[0]
+	}
+	opt = opt.withDefaults(1)
+	d := t.Sub(ref)
+	if d >= time.Hour || d <= -time.Hour {
+		local := t.In(ref.Location())
+		refDate := ExtractDateFromDatetime(ref)
+		switch {
+		case DateIsEqual(local, refDate.AddDate(0, 0, -1)):
+			return opt.Locale.Yesterday(local)
+		case DateIsEqual(local, refDate.AddDate(0, 0, 1)):
+			return opt.Locale.Tomorrow(local)
+		}
+	}
+	described, tooShort := opt.humanize(d)
+	switch {
+	case tooShort:
+		return opt.Locale.Now()
+	case d < 0:
+		return opt.Locale.Ago(described)
+	default:
+		return opt.Locale.In(described)
+	}
+}
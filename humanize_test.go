@@ -0,0 +1,62 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanize(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		opts []HumanizeOptions
+		want string
+	}{
+		{"two units", time.Hour*2 + time.Minute*5 + time.Second*3, nil, "2 hours 5 minutes"},
+		{"singular", time.Minute, nil, "1 minute"},
+		{"negative", -time.Second * 30, nil, "30 seconds"},
+		{"precision 3", time.Hour*2 + time.Minute*5 + time.Second*3, []HumanizeOptions{{Precision: 3}}, "2 hours 5 minutes 3 seconds"},
+		{"approximate", DurationDay() * 22, []HumanizeOptions{{Approximate: true}}, "about 3 weeks"},
+		{"approximate exact", DurationWeek() * 3, []HumanizeOptions{{Approximate: true}}, "3 weeks"},
+		{"limited units", DurationDay() * 3, []HumanizeOptions{{Units: []Unit{UnitHour, UnitMinute}}}, "72 hours"},
+		{"below smallest unit", time.Second * 30, []HumanizeOptions{{Units: []Unit{UnitMinute}}}, "0 minutes"},
+		{"unknown units ignored", time.Hour + time.Minute, []HumanizeOptions{{Units: []Unit{Unit(9), UnitMinute, Unit(-1)}}}, "61 minutes"},
+		{"only unknown units", time.Hour + time.Minute, []HumanizeOptions{{Units: []Unit{Unit(9)}}}, "1 hour 1 minute"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Humanize(tt.d, tt.opts...); got != tt.want {
+				t.Errorf("Humanize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type shoutingHumanizer struct{ englishHumanizer }
+
+func (shoutingHumanizer) Ago(duration string) string { return duration + " AGO" }
+
+func TestRelativeTo(t *testing.T) {
+	ref := time.Date(2021, 3, 4, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		t    time.Time
+		opts []HumanizeOptions
+		want string
+	}{
+		{"days ago", ref.AddDate(0, 0, -3), nil, "3 days ago"},
+		{"in hours", ref.Add(time.Hour * 2), nil, "in 2 hours"},
+		{"minutes ago", ref.Add(-time.Minute * 5), nil, "5 minutes ago"},
+		{"now", ref.Add(time.Millisecond), nil, "just now"},
+		{"yesterday", time.Date(2021, 3, 3, 9, 15, 0, 0, time.UTC), nil, "yesterday at 09:15"},
+		{"tomorrow", time.Date(2021, 3, 5, 9, 15, 0, 0, time.UTC), nil, "tomorrow at 09:15"},
+		{"locale hook", ref.AddDate(0, 0, -3), []HumanizeOptions{{Locale: shoutingHumanizer{}}}, "3 days AGO"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RelativeTo(tt.t, ref, tt.opts...); got != tt.want {
+				t.Errorf("RelativeTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
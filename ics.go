@@ -0,0 +1,359 @@
+package datetime
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//Event is a VEVENT of an iCalendar file, like a maintenance window or a holiday
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	//End is excluded, so an all day event on one date ends at midnight of the next
+	End time.Time
+	//AllDay events have DATE values rather than DATE-TIME, and cover whole days in the location of Start
+	AllDay bool
+	//Recurrence repeats the event, nil if it happens once. Its Dtstart is Start
+	Recurrence *RRuleSet
+}
+
+//occurrenceEnd returns the end of the occurrence starting at start, keeping whole days for all day events
+func (e Event) occurrenceEnd(start time.Time) time.Time {
+	if e.AllDay {
+		return start.AddDate(0, 0, calendarDays(e.Start, e.End))
+	}
+	return start.Add(e.End.Sub(e.Start))
+}
+
+//Occurrences returns the ranges of the event overlapping window, expanding its recurrence
+func (e Event) Occurrences(window Range) []Range {
+	ranges := []Range{}
+	add := func(start time.Time) {
+		r := Range{Start: start, End: e.occurrenceEnd(start)}
+		if r.Start.Before(window.End) && (r.End.After(window.Start) || (r.IsEmpty() && !r.Start.Before(window.Start))) {
+			ranges = append(ranges, r)
+		}
+	}
+	if e.Recurrence == nil {
+		add(e.Start)
+		return ranges
+	}
+	//occurrences starting before the window may still reach into it
+	lookBack := e.occurrenceEnd(e.Start).Sub(e.Start) + DurationDay()
+	for _, start := range CollectBetween(e.Recurrence.Iterator(), window.Start.Add(-lookBack), window.End) {
+		add(start)
+	}
+	return ranges
+}
+
+//ExpandEvents returns the ranges of all events overlapping window, sorted by start
+func ExpandEvents(events []Event, window Range) []Range {
+	ranges := []Range{}
+	for _, e := range events {
+		ranges = append(ranges, e.Occurrences(window)...)
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].Start.Before(ranges[j].Start) })
+	return ranges
+}
+
+//HolidayDates returns the dates covered by all day events in window, sorted and without repeats, as midnight in their location
+//so a calendar of holidays can be checked with DatetimeIsInArray or a TimeIndex
+func HolidayDates(events []Event, window Range) []time.Time {
+	dates := []time.Time{}
+	seen := map[string]bool{}
+	for _, e := range events {
+		if !e.AllDay {
+			continue
+		}
+		for _, r := range e.Occurrences(window) {
+			for d := r.Start; d.Before(r.End); d = d.AddDate(0, 0, 1) {
+				if key := d.Format(rruleDateLayout); !seen[key] && window.Contains(d) {
+					seen[key] = true
+					dates = append(dates, d)
+				}
+			}
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates
+}
+
+//unfoldICS reads the content lines of an iCalendar file, joining lines folded onto lines starting with a space or tab
+func unfoldICS(r io.Reader) ([]string, error) {
+	lines := []string{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) != 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+var icsTextUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+//parseICSDuration parses an RFC 5545 DURATION like "PT1H30M", "P1D" or "-P1W"
+func parseICSDuration(value string) (time.Duration, error) {
+	s := value
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign, s = -1, s[1:]
+	}
+	s = strings.TrimPrefix(s, "+")
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, fmt.Errorf("invalid DURATION %q", value)
+	}
+	units := map[byte]time.Duration{'W': DurationWeek(), 'D': DurationDay(), 'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+	var d time.Duration
+	number, inTime := "", false
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == 'T':
+			inTime = true
+		case c >= '0' && c <= '9':
+			number += string(c)
+		default:
+			unit, exists := units[c]
+			n, err := strconv.Atoi(number)
+			//M is minutes only after T, RFC 5545 has no months
+			if !exists || err != nil || (c == 'M' && !inTime) {
+				return 0, fmt.Errorf("invalid DURATION %q", value)
+			}
+			d += time.Duration(n) * unit
+			number = ""
+		}
+	}
+	if number != "" {
+		return 0, fmt.Errorf("invalid DURATION %q", value)
+	}
+	return sign * d, nil
+}
+
+//ReadICS reads the VEVENTs of an iCalendar file, with their DTSTART, DTEND or DURATION, RRULE, RDATE, EXDATE, SUMMARY and UID
+//times with a TZID are read in that IANA zone, or the zone of a Windows name in WindowsZoneNames, floating times and dates in loc, UTC if not provided.
+//any other TZID is an error, as VTIMEZONE definitions are not read. Other components like VTODO, and those inside a VEVENT like VALARM, are skipped
+func ReadICS(r io.Reader, loc ...*time.Location) ([]Event, error) {
+	floating := time.UTC
+	if len(loc) != 0 {
+		floating = loc[0]
+	}
+	lines, err := unfoldICS(r)
+	if err != nil {
+		return nil, fmt.Errorf("(ReadICS) %v", err)
+	}
+	events := []Event{}
+	var e *Event
+	var duration *time.Duration
+	rules := []string{}
+	//nested counts the components open inside the event, like a VALARM, whose properties are not the event's
+	nested := 0
+	for n, line := range lines {
+		name, params, value, err := parseContentLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("(ReadICS) line %v: %v", n+1, err)
+		}
+		if e == nil {
+			if name == "BEGIN" && strings.EqualFold(value, "VEVENT") {
+				e, duration, rules, nested = &Event{}, nil, []string{}, 0
+			}
+			continue
+		}
+		if name == "BEGIN" {
+			nested++
+			continue
+		}
+		if nested != 0 {
+			if name == "END" {
+				nested--
+			}
+			continue
+		}
+		switch name {
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			if err := e.finish(duration, rules); err != nil {
+				return nil, fmt.Errorf("(ReadICS) event ending on line %v: %v", n+1, err)
+			}
+			events = append(events, *e)
+			e = nil
+		case "UID":
+			e.UID = value
+		case "SUMMARY":
+			e.Summary = icsTextUnescaper.Replace(value)
+		case "DTSTART", "DTEND", "RDATE", "EXDATE":
+			times, err := parseContentTimes(value, params, floating)
+			if err != nil {
+				return nil, fmt.Errorf("(ReadICS) line %v: invalid %v %q: %v", n+1, name, value, err)
+			}
+			switch name {
+			case "DTSTART":
+				e.Start, e.AllDay = times[0], params["VALUE"] == "DATE" || len(value) == len(rruleDateLayout)
+			case "DTEND":
+				e.End = times[0]
+			case "RDATE":
+				e.recurrence().RDates = append(e.recurrence().RDates, times...)
+			case "EXDATE":
+				e.recurrence().ExDates = append(e.recurrence().ExDates, times...)
+			}
+		case "DURATION":
+			d, err := parseICSDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("(ReadICS) line %v: %v", n+1, err)
+			}
+			duration = &d
+		case "RRULE":
+			rules = append(rules, value)
+		}
+	}
+	if e != nil {
+		return nil, fmt.Errorf("(ReadICS) VEVENT is not ended")
+	}
+	return events, nil
+}
+
+func (e *Event) recurrence() *RRuleSet {
+	if e.Recurrence == nil {
+		e.Recurrence = &RRuleSet{}
+	}
+	return e.Recurrence
+}
+
+//finish checks a parsed event and fills in its end and recurrence, which depend on DTSTART
+func (e *Event) finish(duration *time.Duration, rules []string) error {
+	if e.Start.IsZero() {
+		return fmt.Errorf("DTSTART is required")
+	}
+	switch {
+	case !e.End.IsZero():
+	case duration != nil && e.AllDay:
+		e.End = e.Start.AddDate(0, 0, int(*duration/DurationDay()))
+	case duration != nil:
+		e.End = e.Start.Add(*duration)
+	case e.AllDay:
+		e.End = e.Start.AddDate(0, 0, 1)
+	default:
+		e.End = e.Start
+	}
+	if e.End.Before(e.Start) {
+		return fmt.Errorf("DTEND %v is before DTSTART %v", e.End, e.Start)
+	}
+	for _, rule := range rules {
+		r, err := ParseRRule(rule, e.Start)
+		if err != nil {
+			return err
+		}
+		e.recurrence().RRules = append(e.recurrence().RRules, r)
+	}
+	if e.Recurrence != nil {
+		//DTSTART is always the first occurrence, even if the rules do not match it
+		e.Recurrence.Dtstart = e.Start
+		e.Recurrence.RDates = append(e.Recurrence.RDates, e.Start)
+	}
+	return nil
+}
+
+//EventsFromRanges creates an event for every range, like maintenance windows, named summary
+func EventsFromRanges(ranges []Range, summary string) []Event {
+	events := make([]Event, len(ranges))
+	for i, r := range ranges {
+		events[i] = Event{Summary: summary, Start: r.Start, End: r.End}
+	}
+	return events
+}
+
+//HolidayEvents creates an all day event for every date of dates, named summary
+func HolidayEvents(dates []time.Time, summary string) []Event {
+	events := make([]Event, len(dates))
+	for i, d := range dates {
+		date := ExtractDateFromDatetime(d)
+		events[i] = Event{Summary: summary, Start: date, End: date.AddDate(0, 0, 1), AllDay: true}
+	}
+	return events
+}
+
+//foldICS folds a content line to lines of at most 75 octets, without splitting UTF-8 characters
+func foldICS(line string) string {
+	var folded strings.Builder
+	width := 75
+	for len(line) > width {
+		cut := width
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		folded.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		//continuation lines start with a space, which counts towards their 75 octets
+		width = 74
+	}
+	folded.WriteString(line)
+	return folded.String()
+}
+
+func formatICSTime(name string, t time.Time, allDay bool) string {
+	if allDay {
+		return name + ";VALUE=DATE:" + t.Format(rruleDateLayout)
+	}
+	return formatContentTimes(name, t)
+}
+
+//WriteICS writes events as an iCalendar file. Events without a UID get one made from their start and position
+//times in a zone other than UTC are written with a TZID, which readers resolve with their own timezone database
+func WriteICS(w io.Writer, events []Event) error {
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//devshoe//datetime-go//EN", "CALSCALE:GREGORIAN"}
+	stamp := time.Now().UTC().Format(rruleUTCLayout)
+	for i, e := range events {
+		uid := e.UID
+		if uid == "" {
+			uid = fmt.Sprintf("%v-%v@datetime-go", e.Start.UTC().Format(rruleUTCLayout), i)
+		}
+		lines = append(lines, "BEGIN:VEVENT", "UID:"+uid, "DTSTAMP:"+stamp, formatICSTime("DTSTART", e.Start, e.AllDay))
+		if !e.End.IsZero() {
+			lines = append(lines, formatICSTime("DTEND", e.End, e.AllDay))
+		}
+		if e.Summary != "" {
+			lines = append(lines, "SUMMARY:"+icsTextEscaper.Replace(e.Summary))
+		}
+		if e.Recurrence != nil {
+			for _, r := range e.Recurrence.RRules {
+				lines = append(lines, "RRULE:"+r.String())
+			}
+			rdates := []time.Time{}
+			for _, t := range e.Recurrence.RDates {
+				//DTSTART is implied, see ReadICS
+				if !t.Equal(e.Start) {
+					rdates = append(rdates, t)
+				}
+			}
+			if len(rdates) != 0 {
+				lines = append(lines, formatContentTimes("RDATE", rdates...))
+			}
+			if len(e.Recurrence.ExDates) != 0 {
+				lines = append(lines, formatContentTimes("EXDATE", e.Recurrence.ExDates...))
+			}
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+	for _, line := range lines {
+		if _, err := io.WriteString(w, foldICS(line)+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
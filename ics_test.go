@@ -0,0 +1,263 @@
+package datetime
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//ops//maintenance//EN\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:America/New_York\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:patch@ops\r\n" +
+	"DTSTART;TZID=America/New_York:20210302T220000\r\n" +
+	"DTEND;TZID=America/New_York:20210303T010000\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=TU;COUNT=4\r\n" +
+	"EXDATE;TZID=America/New_York:20210316T220000\r\n" +
+	"SUMMARY:Patch window\\, database\r\n" +
+	"  servers\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:holiday@ops\r\n" +
+	"DTSTART;VALUE=DATE:20210704\r\n" +
+	"RRULE:FREQ=YEARLY\r\n" +
+	"SUMMARY:Independence Day\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:freeze@ops\r\n" +
+	"DTSTART;VALUE=DATE:20211224\r\n" +
+	"DURATION:P3D\r\n" +
+	"SUMMARY:Change freeze\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"DTSTART:20210101T000000Z\r\n" +
+	"END:VTODO\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestReadICS(t *testing.T) {
+	loc := newYork(t)
+	events, err := ReadICS(strings.NewReader(sampleICS), loc)
+	if err != nil {
+		t.Fatalf("ReadICS() error = %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("ReadICS() got %v events, want 3", len(events))
+	}
+	patch := events[0]
+	if patch.Summary != "Patch window, database servers" || patch.AllDay {
+		t.Errorf("ReadICS() patch event = %+v", patch)
+	}
+	if want := time.Date(2021, 3, 3, 1, 0, 0, 0, loc); !patch.End.Equal(want) {
+		t.Errorf("ReadICS() patch end = %v, want %v", patch.End, want)
+	}
+	freeze := events[2]
+	if !freeze.AllDay || freeze.Recurrence != nil || !freeze.End.Equal(time.Date(2021, 12, 27, 0, 0, 0, 0, loc)) {
+		t.Errorf("ReadICS() freeze event = %+v", freeze)
+	}
+
+	//daylight saving starts on March 14th, and the windows stay at 22:00 New York time
+	window := Range{Start: time.Date(2021, 3, 1, 0, 0, 0, 0, loc), End: time.Date(2021, 4, 1, 0, 0, 0, 0, loc)}
+	got := patch.Occurrences(window)
+	want := []time.Time{time.Date(2021, 3, 2, 22, 0, 0, 0, loc), time.Date(2021, 3, 9, 22, 0, 0, 0, loc), time.Date(2021, 3, 23, 22, 0, 0, 0, loc)}
+	if len(got) != len(want) {
+		t.Fatalf("Occurrences() = %v, want starts %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i]) || got[i].Duration() != 3*time.Hour {
+			t.Errorf("Occurrences()[%v] = %v, want 3 hours from %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestReadICSNestedComponents(t *testing.T) {
+	loc := newYork(t)
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:standup@ops\r\n" +
+		"DTSTART;TZID=Eastern Standard Time:20210302T093000\r\n" +
+		"DURATION:PT15M\r\n" +
+		"SUMMARY:Standup\r\n" +
+		"BEGIN:VALARM\r\n" +
+		"ACTION:DISPLAY\r\n" +
+		"SUMMARY:Reminder\r\n" +
+		"DESCRIPTION:Standup in 5 minutes\r\n" +
+		"DURATION:PT5M\r\n" +
+		"TRIGGER:-PT5M\r\n" +
+		"END:VALARM\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	events, err := ReadICS(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("ReadICS() error = %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("ReadICS() got %v events, want 1", len(events))
+	}
+	//the alarm's SUMMARY and DURATION are not the event's, and the Windows zone name is New York
+	start := time.Date(2021, 3, 2, 9, 30, 0, 0, loc)
+	if e := events[0]; e.Summary != "Standup" || !e.Start.Equal(start) || e.Start.Location().String() != "America/New_York" || e.End.Sub(e.Start) != 15*time.Minute {
+		t.Errorf("ReadICS() event = %+v, want Standup for 15 minutes from %v", e, start)
+	}
+}
+
+func TestEventOccurrencesOverlap(t *testing.T) {
+	start := time.Date(2021, 1, 1, 22, 0, 0, 0, time.UTC)
+	e := Event{Start: start, End: start.Add(4 * time.Hour), Recurrence: &RRuleSet{}}
+	rule, err := ParseRRule("FREQ=DAILY;COUNT=5", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Recurrence.RRules = []*RRule{rule}
+	tests := []struct {
+		name   string
+		window Range
+		want   int
+	}{
+		{"window starting inside an occurrence", Range{Start: time.Date(2021, 1, 2, 1, 0, 0, 0, time.UTC), End: time.Date(2021, 1, 2, 2, 0, 0, 0, time.UTC)}, 1},
+		{"window between occurrences", Range{Start: time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC), End: time.Date(2021, 1, 2, 21, 0, 0, 0, time.UTC)}, 0},
+		{"window covering all", Range{Start: start.AddDate(0, 0, -1), End: start.AddDate(0, 0, 10)}, 5},
+		{"window ending at a start", Range{Start: start.AddDate(0, 0, -1), End: start}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := e.Occurrences(tt.window); len(got) != tt.want {
+				t.Errorf("Occurrences() = %v, want %v ranges", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHolidayDates(t *testing.T) {
+	loc := newYork(t)
+	events, err := ReadICS(strings.NewReader(sampleICS), loc)
+	if err != nil {
+		t.Fatalf("ReadICS() error = %v", err)
+	}
+	window := Range{Start: time.Date(2021, 1, 1, 0, 0, 0, 0, loc), End: time.Date(2022, 12, 26, 0, 0, 0, 0, loc)}
+	got := HolidayDates(events, window)
+	want := []time.Time{
+		time.Date(2021, 7, 4, 0, 0, 0, 0, loc),
+		time.Date(2021, 12, 24, 0, 0, 0, 0, loc), time.Date(2021, 12, 25, 0, 0, 0, 0, loc), time.Date(2021, 12, 26, 0, 0, 0, 0, loc),
+		time.Date(2022, 7, 4, 0, 0, 0, 0, loc),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("HolidayDates() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("HolidayDates()[%v] = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if !DatetimeIsInArray(time.Date(2022, 7, 4, 0, 0, 0, 0, loc), got) {
+		t.Errorf("DatetimeIsInArray() = false for a holiday")
+	}
+}
+
+func TestReadICSErrors(t *testing.T) {
+	event := func(lines ...string) string {
+		return "BEGIN:VCALENDAR\nBEGIN:VEVENT\n" + strings.Join(lines, "\n") + "\nEND:VEVENT\nEND:VCALENDAR\n"
+	}
+	tests := []struct {
+		name string
+		ics  string
+	}{
+		{"no start", event("SUMMARY:nothing")},
+		{"end before start", event("DTSTART:20210102T000000Z", "DTEND:20210101T000000Z")},
+		{"bad time", event("DTSTART:2021-01-01")},
+		{"bad duration", event("DTSTART:20210101T000000Z", "DURATION:P1M")},
+		{"bad rule", event("DTSTART:20210101T000000Z", "RRULE:FREQ=SOMETIMES")},
+		{"unknown zone", event("DTSTART;TZID=Mars/Olympus:20210101T000000")},
+		{"not ended", "BEGIN:VEVENT\nDTSTART:20210101T000000Z\n"},
+		{"no value", event("DTSTART")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadICS(strings.NewReader(tt.ics)); err == nil {
+				t.Errorf("ReadICS() expected an error")
+			}
+		})
+	}
+}
+
+func TestParseICSDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"PT1H30M", 90 * time.Minute},
+		{"P1D", DurationDay()},
+		{"P1DT12H", 36 * time.Hour},
+		{"-PT15M", -15 * time.Minute},
+		{"P2W", 2 * DurationWeek()},
+		{"PT45S", 45 * time.Second},
+	}
+	for _, tt := range tests {
+		got, err := parseICSDuration(tt.value)
+		if err != nil || got != tt.want {
+			t.Errorf("parseICSDuration(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestWriteICS(t *testing.T) {
+	loc := newYork(t)
+	start := time.Date(2021, 3, 2, 22, 0, 0, 0, loc)
+	rule, err := ParseRRule("FREQ=WEEKLY;BYDAY=TU;COUNT=4", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := []Event{
+		{UID: "patch@ops", Summary: "Patch window, database servers; " + strings.Repeat("long ", 20), Start: start, End: start.Add(3 * time.Hour),
+			Recurrence: &RRuleSet{Dtstart: start, RRules: []*RRule{rule}, RDates: []time.Time{start}, ExDates: []time.Time{start.AddDate(0, 0, 14)}}},
+	}
+	events = append(events, EventsFromRanges([]Range{{Start: time.Date(2021, 5, 1, 6, 0, 0, 0, time.UTC), End: time.Date(2021, 5, 1, 8, 0, 0, 0, time.UTC)}}, "Upgrade")...)
+	events = append(events, HolidayEvents([]time.Time{time.Date(2021, 12, 25, 15, 0, 0, 0, loc)}, "Christmas")...)
+
+	var buf bytes.Buffer
+	if err := WriteICS(&buf, events); err != nil {
+		t.Fatalf("WriteICS() error = %v", err)
+	}
+	out := buf.String()
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("WriteICS() line longer than 75 octets: %q", line)
+		}
+	}
+	for _, want := range []string{
+		"DTSTART;TZID=America/New_York:20210302T220000\r\n",
+		"RRULE:FREQ=WEEKLY;COUNT=4;BYDAY=TU\r\n",
+		"EXDATE;TZID=America/New_York:20210316T220000\r\n",
+		"DTSTART:20210501T060000Z\r\n",
+		"DTSTART;VALUE=DATE:20211225\r\nDTEND;VALUE=DATE:20211226\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteICS() output is missing %q:\n%v", want, out)
+		}
+	}
+	if strings.Contains(out, "RDATE") {
+		t.Errorf("WriteICS() wrote DTSTART as an RDATE:\n%v", out)
+	}
+
+	//and back
+	read, err := ReadICS(strings.NewReader(out), loc)
+	if err != nil {
+		t.Fatalf("ReadICS() error = %v", err)
+	}
+	if len(read) != len(events) {
+		t.Fatalf("ReadICS() got %v events, want %v", len(read), len(events))
+	}
+	for i := range events {
+		if read[i].Summary != events[i].Summary || !read[i].Start.Equal(events[i].Start) || !read[i].End.Equal(events[i].End) || read[i].AllDay != events[i].AllDay {
+			t.Errorf("ReadICS() event %v = %+v, want %+v", i, read[i], events[i])
+		}
+	}
+	window := Range{Start: start, End: start.AddDate(1, 0, 0)}
+	if got := len(ExpandEvents(read, window)); got != 5 {
+		t.Errorf("ExpandEvents() got %v ranges, want 5", got)
+	}
+}
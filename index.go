@@ -0,0 +1,184 @@
+package datetime
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+	"time"
+)
+
+//TimeIndex is a slice of times sorted in ascending order, like the index of a time series
+//methods other than IsSorted and Validate assume the index is sorted
+type TimeIndex []time.Time
+
+//DuplicatePolicy decides which of several equal times Unique keeps
+type DuplicatePolicy int
+
+const (
+	//KeepFirst keeps the first of equal times
+	KeepFirst DuplicatePolicy = iota
+	//KeepLast keeps the last of equal times
+	KeepLast
+	//RejectDuplicates makes Unique return an error if there are equal times
+	RejectDuplicates
+)
+
+func checkSorted(fn string, name string, index []time.Time) error {
+	for i := 1; i < len(index); i++ {
+		if index[i].Before(index[i-1]) {
+			return fmt.Errorf("(%v) %v is not sorted at position %v", fn, name, i)
+		}
+	}
+	return nil
+}
+
+//IsSorted reports if the index is in ascending order, equal times are allowed
+func (ix TimeIndex) IsSorted() bool {
+	return checkSorted("IsSorted", "index", ix) == nil
+}
+
+//Validate returns an error if the index is not sorted or contains zero times, usually left by failed parsing
+//if strict is passed as true, equal times are reported too
+func (ix TimeIndex) Validate(strict ...bool) error {
+	for i := range ix {
+		if ix[i].IsZero() {
+			return fmt.Errorf("(Validate) index has zero time at position %v", i)
+		}
+		if i == 0 {
+			continue
+		}
+		if ix[i].Before(ix[i-1]) {
+			return fmt.Errorf("(Validate) index is not sorted at position %v", i)
+		}
+		if strict != nil && strict[0] && ix[i].Equal(ix[i-1]) {
+			return fmt.Errorf("(Validate) index has duplicate time %v at position %v", ix[i], i)
+		}
+	}
+	return nil
+}
+
+//LowerBound returns the position of the first time not before t, or len(ix) if there is none
+func (ix TimeIndex) LowerBound(t time.Time) int {
+	return sort.Search(len(ix), func(i int) bool { return !ix[i].Before(t) })
+}
+
+//UpperBound returns the position of the first time after t, or len(ix) if there is none
+func (ix TimeIndex) UpperBound(t time.Time) int {
+	return sort.Search(len(ix), func(i int) bool { return ix[i].After(t) })
+}
+
+//Locate returns the position of the first time equal to t, and whether it was found, using binary search
+func (ix TimeIndex) Locate(t time.Time) (int, bool) {
+	i := ix.LowerBound(t)
+	return i, i < len(ix) && ix[i].Equal(t)
+}
+
+//Contains reports if this exact time is in the index, like DatetimeIsInArray but in O(log n)
+func (ix TimeIndex) Contains(t time.Time) bool {
+	_, found := ix.Locate(t)
+	return found
+}
+
+//SliceBetween returns the part of the index between t1 and t2, t1 included and t2 excluded like DatetimeIsInRange
+//the returned index shares memory with ix
+func (ix TimeIndex) SliceBetween(t1, t2 time.Time) TimeIndex {
+	lo := ix.LowerBound(t1)
+	hi := ix.LowerBound(t2)
+	if hi < lo {
+		hi = lo
+	}
+	return ix[lo:hi]
+}
+
+//Unique removes equal times according to policy, and returns the positions in ix of the times kept so data columns can follow
+func (ix TimeIndex) Unique(policy DuplicatePolicy) (TimeIndex, []int, error) {
+	unique := TimeIndex{}
+	positions := []int{}
+	for i := range ix {
+		if len(unique) == 0 || !ix[i].Equal(unique[len(unique)-1]) {
+			unique = append(unique, ix[i])
+			positions = append(positions, i)
+			continue
+		}
+		switch policy {
+		case KeepLast:
+			unique[len(unique)-1] = ix[i]
+			positions[len(positions)-1] = i
+		case RejectDuplicates:
+			return nil, nil, fmt.Errorf("(Unique) index has duplicate time %v at position %v", ix[i], i)
+		}
+	}
+	return unique, positions, nil
+}
+
+type mergeCursor struct {
+	index TimeIndex
+	pos   int
+}
+
+type mergeHeap []*mergeCursor
+
+func (h mergeHeap) Len() int { return len(h) }
+func (h mergeHeap) Less(i, j int) bool {
+	return h[i].index[h[i].pos].Before(h[j].index[h[j].pos])
+}
+func (h mergeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x interface{}) { *h = append(*h, x.(*mergeCursor)) }
+func (h *mergeHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+//Merge combines sorted indices into a single sorted index, keeping duplicates, in O(n log k)
+func Merge(indices ...TimeIndex) TimeIndex {
+	h := mergeHeap{}
+	total := 0
+	for _, ix := range indices {
+		if len(ix) != 0 {
+			h = append(h, &mergeCursor{index: ix})
+			total += len(ix)
+		}
+	}
+	heap.Init(&h)
+	merged := make(TimeIndex, 0, total)
+	for h.Len() != 0 {
+		c := h[0]
+		merged = append(merged, c.index[c.pos])
+		c.pos++
+		if c.pos == len(c.index) {
+			heap.Pop(&h)
+		} else {
+			heap.Fix(&h, 0)
+		}
+	}
+	return merged
+}
+
+//Union returns the sorted times present in any of a and b, without duplicates
+func Union(a, b TimeIndex) TimeIndex {
+	unique, _, _ := Merge(a, b).Unique(KeepFirst)
+	return unique
+}
+
+//Intersection returns the sorted times present in both a and b, without duplicates
+func Intersection(a, b TimeIndex) TimeIndex {
+	common := TimeIndex{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i].Before(b[j]):
+			i++
+		case b[j].Before(a[i]):
+			j++
+		default:
+			if len(common) == 0 || !common[len(common)-1].Equal(a[i]) {
+				common = append(common, a[i])
+			}
+			i++
+			j++
+		}
+	}
+	return common
+}
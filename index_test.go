@@ -0,0 +1,96 @@
+package datetime
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func minutesIndex(minutes ...int) TimeIndex {
+	start := time.Date(2021, 1, 1, 9, 15, 0, 0, time.UTC)
+	ix := TimeIndex{}
+	for _, m := range minutes {
+		ix = append(ix, start.Add(time.Minute*time.Duration(m)))
+	}
+	return ix
+}
+
+func TestTimeIndexSearch(t *testing.T) {
+	ix := minutesIndex(0, 2, 2, 5)
+	at := minutesIndex(2)[0]
+	if got := ix.LowerBound(at); got != 1 {
+		t.Errorf("TimeIndex.LowerBound() = %v, want 1", got)
+	}
+	if got := ix.UpperBound(at); got != 3 {
+		t.Errorf("TimeIndex.UpperBound() = %v, want 3", got)
+	}
+	if got, found := ix.Locate(minutesIndex(3)[0]); found || got != 3 {
+		t.Errorf("TimeIndex.Locate() = %v, %v, want 3, false", got, found)
+	}
+	if got := ix.SliceBetween(at, minutesIndex(5)[0]); !reflect.DeepEqual(got, ix[1:3]) {
+		t.Errorf("TimeIndex.SliceBetween() = %v, want %v", got, ix[1:3])
+	}
+}
+
+func TestTimeIndexValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ix      TimeIndex
+		strict  []bool
+		wantErr bool
+	}{
+		{"sorted", minutesIndex(0, 1, 1), nil, false},
+		{"strict duplicates", minutesIndex(0, 1, 1), []bool{true}, true},
+		{"unsorted", minutesIndex(1, 0), nil, true},
+		{"zero time", TimeIndex{time.Time{}}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.ix.Validate(tt.strict...); (err != nil) != tt.wantErr {
+				t.Errorf("TimeIndex.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTimeIndexUnique(t *testing.T) {
+	ix := minutesIndex(0, 1, 1, 1, 3)
+	tests := []struct {
+		name          string
+		policy        DuplicatePolicy
+		wantPositions []int
+		wantErr       bool
+	}{
+		{"keep first", KeepFirst, []int{0, 1, 4}, false},
+		{"keep last", KeepLast, []int{0, 3, 4}, false},
+		{"reject", RejectDuplicates, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, positions, err := ix.Unique(tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TimeIndex.Unique() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(positions, tt.wantPositions) {
+				t.Errorf("TimeIndex.Unique() positions = %v, want %v", positions, tt.wantPositions)
+			}
+			if err == nil && !reflect.DeepEqual(got, minutesIndex(0, 1, 3)) {
+				t.Errorf("TimeIndex.Unique() = %v", got)
+			}
+		})
+	}
+}
+
+func TestMergeUnionIntersection(t *testing.T) {
+	a, b, c := minutesIndex(0, 3, 6), minutesIndex(1, 3, 4), minutesIndex(2)
+	if got, want := Merge(a, b, c, nil), minutesIndex(0, 1, 2, 3, 3, 4, 6); !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %v, want %v", got, want)
+	}
+	if got, want := Union(a, b), minutesIndex(0, 1, 3, 4, 6); !reflect.DeepEqual(got, want) {
+		t.Errorf("Union() = %v, want %v", got, want)
+	}
+	if got, want := Intersection(a, b), minutesIndex(3); !reflect.DeepEqual(got, want) {
+		t.Errorf("Intersection() = %v, want %v", got, want)
+	}
+}
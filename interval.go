@@ -0,0 +1,127 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+//Interval is a time.Duration which marshals to and from interval strings like "15m", "1d" or "2w" rather than nanoseconds
+//so configuration files can say "interval": "15m". It implements json.Marshaler, encoding.TextMarshaler, flag.Value, sql.Scanner and driver.Valuer
+type Interval time.Duration
+
+var intervalUnits = []struct {
+	suffix string
+	unit   time.Duration
+}{
+	{"w", DurationWeek()},
+	{"d", DurationDay()},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+}
+
+//ParseIntervalValue parses any string time.ParseDuration accepts, like "1h30m" or "500ms", and otherwise any string ParseInterval accepts, like "1day" or "minute"
+//a leading "-" negates either, so every Interval.String parses back, "-1d" included
+func ParseIntervalValue(interval string) (Interval, error) {
+	interval = strings.TrimSpace(interval)
+	if d, err := time.ParseDuration(interval); err == nil {
+		return Interval(d), nil
+	}
+	negative := strings.HasPrefix(interval, "-")
+	d, err := ParseInterval(strings.TrimPrefix(interval, "-"))
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		d = -d
+	}
+	return Interval(d), nil
+}
+
+//Duration returns the interval as a time.Duration
+func (i Interval) Duration() time.Duration {
+	return time.Duration(i)
+}
+
+//String formats the interval with the largest unit out of w, d, h, m and s dividing it, like "15m" or "2w"
+//intervals which are not whole seconds are formatted like time.Duration
+func (i Interval) String() string {
+	d := time.Duration(i)
+	if d == 0 {
+		return "0s"
+	}
+	for _, u := range intervalUnits {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d%v", d/u.unit, u.suffix)
+		}
+	}
+	return d.String()
+}
+
+//Set parses interval into i, implementing flag.Value
+func (i *Interval) Set(interval string) error {
+	parsed, err := ParseIntervalValue(interval)
+	if err != nil {
+		return err
+	}
+	*i = parsed
+	return nil
+}
+
+//MarshalText implements encoding.TextMarshaler
+func (i Interval) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler
+func (i *Interval) UnmarshalText(text []byte) error {
+	return i.Set(string(text))
+}
+
+//MarshalJSON implements json.Marshaler, writing the interval as a string
+func (i Interval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+//UnmarshalJSON implements json.Unmarshaler. Strings are parsed with ParseIntervalValue, and numbers are read as nanoseconds
+func (i *Interval) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return i.Set(s)
+	}
+	var ns int64
+	if err := json.Unmarshal(data, &ns); err != nil {
+		return fmt.Errorf("(Interval) cannot unmarshal %s: must be a string or nanoseconds", data)
+	}
+	*i = Interval(ns)
+	return nil
+}
+
+//Scan implements sql.Scanner, accepting strings and nanoseconds
+func (i *Interval) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*i = 0
+		return nil
+	case string:
+		return i.Set(src)
+	case []byte:
+		return i.Set(string(src))
+	case int64:
+		*i = Interval(src)
+		return nil
+	default:
+		return fmt.Errorf("(Interval) cannot scan %v of type %T", src, src)
+	}
+}
+
+//Value implements driver.Valuer, storing the interval as a string
+func (i Interval) Value() (driver.Value, error) {
+	return i.String(), nil
+}
@@ -0,0 +1,97 @@
+package datetime
+
+import (
+	"encoding/json"
+	"flag"
+	"testing"
+	"time"
+)
+
+func TestIntervalString(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval Interval
+		want     string
+	}{
+		{"minutes", Interval(time.Minute * 15), "15m"},
+		{"days", Interval(DurationDay() * 3), "3d"},
+		{"weeks", Interval(DurationWeek() * 2), "2w"},
+		{"mixed", Interval(time.Minute * 90), "90m"},
+		{"sub second", Interval(time.Millisecond * 1500), "1.5s"},
+		{"zero", 0, "0s"},
+		{"negative days", Interval(-DurationDay()), "-1d"},
+		{"negative weeks", Interval(-DurationWeek() * 3), "-3w"},
+		{"negative minutes", Interval(-time.Minute * 15), "-15m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.interval.String(); got != tt.want {
+				t.Errorf("Interval.String() = %v, want %v", got, tt.want)
+			}
+			parsed, err := ParseIntervalValue(tt.want)
+			if err != nil || parsed != tt.interval {
+				t.Errorf("ParseIntervalValue(%v) = %v, %v, want %v", tt.want, parsed, err, tt.interval)
+			}
+		})
+	}
+}
+
+func TestIntervalJSON(t *testing.T) {
+	type config struct {
+		Interval Interval `json:"interval"`
+	}
+	tests := []struct {
+		name    string
+		input   string
+		want    Interval
+		wantErr bool
+	}{
+		{"short", `{"interval": "15m"}`, Interval(time.Minute * 15), false},
+		{"long", `{"interval": "1day"}`, Interval(DurationDay()), false},
+		{"no digits", `{"interval": "hour"}`, Interval(time.Hour), false},
+		{"compound", `{"interval": "1h30m"}`, Interval(time.Minute * 90), false},
+		{"nanoseconds", `{"interval": 60000000000}`, Interval(time.Minute), false},
+		{"negative", `{"interval": "-1w"}`, Interval(-DurationWeek()), false},
+		{"year", `{"interval": "1y"}`, Interval(DurationDay() * 365), false},
+		{"invalid", `{"interval": "15"}`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c config
+			err := json.Unmarshal([]byte(tt.input), &c)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("json.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if c.Interval != tt.want {
+				t.Errorf("json.Unmarshal() = %v, want %v", c.Interval, tt.want)
+			}
+		})
+	}
+	out, _ := json.Marshal(config{Interval(time.Minute * 15)})
+	if string(out) != `{"interval":"15m"}` {
+		t.Errorf("json.Marshal() = %s", out)
+	}
+}
+
+func TestIntervalFlagAndSQL(t *testing.T) {
+	var i Interval
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&i, "interval", "")
+	if err := fs.Parse([]string{"-interval", "2d"}); err != nil || i != Interval(DurationDay()*2) {
+		t.Errorf("flag parse = %v, %v", i, err)
+	}
+	if err := i.Scan([]byte("5m")); err != nil || i != Interval(time.Minute*5) {
+		t.Errorf("Interval.Scan() = %v, %v", i, err)
+	}
+	if v, _ := i.Value(); v != "5m" {
+		t.Errorf("Interval.Value() = %v", v)
+	}
+
+	//negative intervals keep their sign through Value and Scan
+	neg := Interval(-DurationDay())
+	v, _ := neg.Value()
+	if err := i.Scan(v); err != nil || i != neg {
+		t.Errorf("Interval.Scan(%v) = %v, %v, want %v", v, i, err, neg)
+	}
+}
@@ -0,0 +1,181 @@
+package datetime
+
+import (
+	"fmt"
+	"time"
+)
+
+//Iterator is a lazily generated sequence of times. Use it like a bufio.Scanner
+//
+//	for it.Next() {
+//		t := it.Time()
+//	}
+type Iterator interface {
+	//Next advances to the next time, returning false once the sequence is exhausted
+	Next() bool
+	//Time returns the time Next advanced to
+	Time() time.Time
+}
+
+//TimeIterator lazily generates times spaced by a fixed interval, without allocating the whole range
+//the i-th time is computed as start + i*interval so no error accumulates on long ranges
+//in wall clock mode, see NewWallClockTimeIterator, it is computed with AddWallClock instead
+type TimeIterator struct {
+	start     time.Time
+	interval  time.Duration
+	length    int
+	pos       int
+	reverse   bool
+	current   time.Time
+	wallClock bool
+	policy    DSTPolicy
+	err       error
+}
+
+//NewTimeIterator iterates from startTime towards endTime in steps of interval
+//endTime is excluded unless inclusive is passed as true. A negative interval iterates backwards, so endTime must then be before startTime
+//an error is returned if interval is 0 or moves away from endTime
+func NewTimeIterator(startTime, endTime time.Time, interval time.Duration, inclusive ...bool) (*TimeIterator, error) {
+	if interval == 0 {
+		return nil, fmt.Errorf("(NewTimeIterator) interval must not be 0")
+	}
+	span := endTime.Sub(startTime)
+	if span != 0 && (span > 0) != (interval > 0) {
+		return nil, fmt.Errorf("(NewTimeIterator) interval %v never reaches %v from %v", interval, endTime, startTime)
+	}
+	length := int(span/interval) + 1
+	if (inclusive == nil || !inclusive[0]) && span%interval == 0 {
+		length--
+	}
+	return &TimeIterator{start: startTime, interval: interval, length: length}, nil
+}
+
+//NewWallClockTimeIterator is NewTimeIterator where steps of a day or longer keep the local clock time of startTime
+//in its location, so a daily range stays at 09:00 across daylight saving changes instead of drifting by an hour.
+//shorter steps are elapsed time and do not keep the clock, so 2h steps from midnight fall on 03:00 and 05:00 after clocks go forward
+//local times skipped or repeated by a change are resolved with policy, see ResolveLocal.
+//with DSTReject, Next stops at the first such time and Err reports it
+func NewWallClockTimeIterator(startTime, endTime time.Time, interval time.Duration, policy DSTPolicy, inclusive ...bool) (*TimeIterator, error) {
+	it, err := NewTimeIterator(startTime, endTime, interval, inclusive...)
+	if err != nil {
+		return nil, err
+	}
+	it.wallClock, it.policy = true, policy
+	//steps are no longer equal, so the length estimated from elapsed time is corrected by looking at the ends
+	inRange := func(t time.Time) bool {
+		if inclusive != nil && inclusive[0] && t.Equal(endTime) {
+			return true
+		}
+		if interval > 0 {
+			return t.Before(endTime)
+		}
+		return t.After(endTime)
+	}
+	for it.length > 0 && !inRange(it.At(it.length-1)) {
+		it.length--
+	}
+	for inRange(it.At(it.length)) {
+		it.length++
+	}
+	return it, nil
+}
+
+//NewWallClockTimeIteratorN is NewTimeIteratorN in wall clock mode, see NewWallClockTimeIterator
+func NewWallClockTimeIteratorN(startTime time.Time, interval time.Duration, length int, policy DSTPolicy) (*TimeIterator, error) {
+	it, err := NewTimeIteratorN(startTime, interval, length)
+	if err != nil {
+		return nil, err
+	}
+	it.wallClock, it.policy = true, policy
+	return it, nil
+}
+
+//NewTimeIteratorN iterates `length` times from startTime in steps of interval, backwards if interval is negative
+//an error is returned if interval is 0
+func NewTimeIteratorN(startTime time.Time, interval time.Duration, length int) (*TimeIterator, error) {
+	if interval == 0 {
+		return nil, fmt.Errorf("(NewTimeIteratorN) interval must not be 0")
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("(NewTimeIteratorN) length must not be negative, got %v", length)
+	}
+	return &TimeIterator{start: startTime, interval: interval, length: length}, nil
+}
+
+//Next advances the iterator, returning false when all times have been generated
+func (it *TimeIterator) Next() bool {
+	if it.pos >= it.length {
+		return false
+	}
+	i := it.pos
+	if it.reverse {
+		i = it.length - 1 - it.pos
+	}
+	current, err := it.at(i)
+	if err != nil && it.policy == DSTReject {
+		it.err = err
+		it.pos = it.length
+		return false
+	}
+	it.current = current
+	it.pos++
+	return true
+}
+
+//Err returns the error which stopped a wall clock iterator with DSTReject, if any
+func (it *TimeIterator) Err() error {
+	return it.err
+}
+
+//Time returns the current time
+func (it *TimeIterator) Time() time.Time {
+	return it.current
+}
+
+//at returns the i-th time. in wall clock mode only steps of a day or longer go through AddWallClock,
+//as a multiple of a shorter step can reach a day and would then be read as whole days
+func (it *TimeIterator) at(i int) (time.Time, error) {
+	if it.wallClock && (it.interval >= DurationDay() || it.interval <= -DurationDay()) {
+		return AddWallClock(it.start, time.Duration(i)*it.interval, it.policy)
+	}
+	return it.start.Add(time.Duration(i) * it.interval), nil
+}
+
+//At returns the i-th time of the range, counting from the start regardless of direction
+//with DSTReject, times skipped or repeated by daylight saving are returned as DSTCompatible would give them
+func (it *TimeIterator) At(i int) time.Time {
+	t, _ := it.at(i)
+	return t
+}
+
+//Len returns the total number of times in the range
+func (it *TimeIterator) Len() int {
+	return it.length
+}
+
+//Reset rewinds the iterator to its first time
+func (it *TimeIterator) Reset() {
+	it.pos = 0
+	it.current = time.Time{}
+	it.err = nil
+}
+
+//Reverse returns a new iterator over the same times in the opposite order, starting from the beginning
+func (it *TimeIterator) Reverse() *TimeIterator {
+	reversed := *it
+	reversed.reverse = !it.reverse
+	reversed.Reset()
+	return &reversed
+}
+
+//Collect drains an Iterator into a slice
+func Collect(it Iterator) []time.Time {
+	t := []time.Time{}
+	if sized, ok := it.(interface{ Len() int }); ok {
+		t = make([]time.Time, 0, sized.Len())
+	}
+	for it.Next() {
+		t = append(t, it.Time())
+	}
+	return t
+}
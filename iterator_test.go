@@ -0,0 +1,141 @@
+package datetime
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewTimeIterator(t *testing.T) {
+	start := time.Date(2020, 12, 12, 9, 15, 0, 0, time.UTC)
+	type args struct {
+		startTime time.Time
+		endTime   time.Time
+		interval  time.Duration
+		inclusive []bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []time.Time
+		wantErr bool
+	}{
+		{"exclusive end", args{start, start.Add(time.Minute * 15), time.Minute * 5, nil}, GenerateTimeRange(start, time.Minute*5, 3), false},
+		{"inclusive end", args{start, start.Add(time.Minute * 15), time.Minute * 5, []bool{true}}, GenerateTimeRange(start, time.Minute*5, 4), false},
+		{"end not on grid", args{start, start.Add(time.Minute * 12), time.Minute * 5, []bool{true}}, GenerateTimeRange(start, time.Minute*5, 3), false},
+		{"negative step", args{start, start.Add(-time.Minute * 10), -time.Minute * 5, []bool{true}}, GenerateTimeRange(start, -time.Minute*5, 3), false},
+		{"empty range", args{start, start, time.Minute, nil}, []time.Time{}, false},
+		{"zero interval", args{start, start.Add(time.Hour), 0, nil}, nil, true},
+		{"wrong direction", args{start, start.Add(time.Hour), -time.Minute, nil}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it, err := NewTimeIterator(tt.args.startTime, tt.args.endTime, tt.args.interval, tt.args.inclusive...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTimeIterator() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got := Collect(it); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewTimeIterator() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeIteratorReverse(t *testing.T) {
+	start := time.Date(2020, 12, 12, 9, 15, 0, 0, time.UTC)
+	it, _ := NewTimeIteratorN(start, time.Hour, 3)
+	got := Collect(it.Reverse())
+	want := []time.Time{start.Add(time.Hour * 2), start.Add(time.Hour), start}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TimeIterator.Reverse() got = %v, want %v", got, want)
+	}
+}
+
+func TestGenerateTimeRangeBetween(t *testing.T) {
+	start := time.Date(2020, 12, 12, 9, 15, 0, 0, time.UTC)
+	if got := GenerateTimeRangeBetween(start, start.Add(time.Hour), 0); len(got) != 0 {
+		t.Errorf("GenerateTimeRangeBetween() with zero interval got %v, want empty", got)
+	}
+	if got := GenerateTimeRangeBetween(start, start.Add(time.Hour), time.Minute*20); len(got) != 3 {
+		t.Errorf("GenerateTimeRangeBetween() got %v, want 3 times", got)
+	}
+	//negative intervals give an empty range, as before iterators, even from a start time after the end time
+	if got := GenerateTimeRangeBetween(start.Add(time.Hour), start, -time.Minute*20); len(got) != 0 {
+		t.Errorf("GenerateTimeRangeBetween() with negative interval got %v, want empty", got)
+	}
+	if _, err := NewTimeIteratorN(start, 0, 3); err == nil {
+		t.Errorf("NewTimeIteratorN() with zero interval should fail")
+	}
+	if got := GenerateTimeRange(start, 0, 3); len(got) != 0 {
+		t.Errorf("GenerateTimeRange() with zero interval got %v, want empty", got)
+	}
+}
+
+func TestNewWallClockTimeIterator(t *testing.T) {
+	loc := newYork(t)
+	start := time.Date(2021, 3, 12, 9, 0, 0, 0, loc)
+	it, err := NewWallClockTimeIterator(start, time.Date(2021, 3, 16, 9, 0, 0, 0, loc), DurationDay(), DSTCompatible, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Collect(it)
+	if len(got) != 5 {
+		t.Fatalf("NewWallClockTimeIterator() got %v times, want 5", len(got))
+	}
+	for i, d := range got {
+		if d.Hour() != 9 || d.Day() != 12+i {
+			t.Errorf("NewWallClockTimeIterator() time %v = %v, want 09:00 on day %v", i, d, 12+i)
+		}
+	}
+	if elapsed := got[2].Sub(got[1]); elapsed != time.Hour*23 {
+		t.Errorf("NewWallClockTimeIterator() step across the change = %v, want 23h", elapsed)
+	}
+
+	//02:30 does not exist on 2021-03-14, but the days after it keep 02:30
+	gapStart := time.Date(2021, 3, 13, 2, 30, 0, 0, loc)
+	it, _ = NewWallClockTimeIteratorN(gapStart, DurationDay(), 3, DSTCompatible)
+	if got := Collect(it); got[1].Hour() != 3 || got[2].Hour() != 2 {
+		t.Errorf("NewWallClockTimeIteratorN() = %v, want 03:30 then 02:30", got)
+	}
+	it, _ = NewWallClockTimeIteratorN(gapStart, DurationDay(), 3, DSTReject)
+	if got := Collect(it); len(got) != 1 || it.Err() == nil {
+		t.Errorf("NewWallClockTimeIteratorN() with DSTReject = %v, %v, want to stop at the gap", got, it.Err())
+	}
+}
+
+func TestNewWallClockTimeIteratorHourly(t *testing.T) {
+	loc := newYork(t)
+	//steps shorter than a day are elapsed time, so local days have 23 and 25 hourly steps across the changes
+	tests := []struct {
+		name string
+		day  time.Time
+		want int
+	}{
+		{"spring forward", time.Date(2021, 3, 14, 0, 0, 0, 0, loc), 23},
+		{"fall back", time.Date(2021, 11, 7, 0, 0, 0, 0, loc), 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it, err := NewWallClockTimeIterator(tt.day.AddDate(0, 0, -1), tt.day.AddDate(0, 0, 2), time.Hour, DSTCompatible)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := Collect(it)
+			if want := 24 + tt.want + 24; len(got) != want {
+				t.Fatalf("NewWallClockTimeIterator() got %v times, want %v", len(got), want)
+			}
+			for i := 1; i < len(got); i++ {
+				if elapsed := got[i].Sub(got[i-1]); elapsed != time.Hour {
+					t.Errorf("NewWallClockTimeIterator() step %v from %v to %v = %v, want 1h", i, got[i-1], got[i], elapsed)
+				}
+			}
+			if !got[24].Equal(tt.day) || !got[24+tt.want].Equal(tt.day.AddDate(0, 0, 1)) {
+				t.Errorf("NewWallClockTimeIterator() day starts at %v and ends at %v, want midnights", got[24], got[24+tt.want])
+			}
+		})
+	}
+}
@@ -13,17 +13,71 @@ var layoutCollection = []string{"2006-01-02T15:04:05Z", "2006-01-02", "2006/01/0
 	"2006-01-02 15:04:05", "2006-01-02T15:04:05Z0700", "2006-01-02T15:04:05Z07:00", time.RFC3339, time.RFC3339Nano,
 }
 
-var layoutConversionMap = map[string]string{
-	"YYYY": "2006",
-	"YY":   "06",
-	"MM":   "01",
-	"DD":   "02",
-	"hh":   "15",
-	"mm":   "04",
-	"ss":   "05",
-	"zh":   "07",
-	"zm":   "00",
-	"nn":   "999999999",
+//layoutConversions maps YYYY like tokens to golang layout tokens
+//longer tokens come first so that YYYY is not read as YY twice
+var layoutConversions = []struct {
+	token  string
+	layout string
+}{
+	{"YYYY", "2006"},
+	{"YY", "06"},
+	{"MM", "01"},
+	{"DD", "02"},
+	{"hh", "15"},
+	{"mm", "04"},
+	{"ss", "05"},
+	{"zh", "07"},
+	{"zm", "00"},
+	{"nn", "999999999"},
+}
+
+//localeLayoutConversions adds the names and markers which FormatDatetimeInLocale and ParseDatetimeInLocale localize,
+//MMMM, MMM, dddd, ddd, Do, II and A, to layoutConversions. They are kept apart so that other layouts keep reading them as literal text
+var localeLayoutConversions = append([]struct {
+	token  string
+	layout string
+}{
+	{"YYYY", "2006"},
+	{"MMMM", "January"},
+	{"dddd", "Monday"},
+	{"MMM", "Jan"},
+	{"ddd", "Mon"},
+	{"Do", "2"},
+	{"II", "03"},
+	{"A", "PM"},
+}, layoutConversions[1:]...)
+
+//layoutToken is either a YYYY like token, with its golang layout, or literal text if token is empty
+type layoutToken struct {
+	token   string
+	layout  string
+	literal string
+}
+
+//tokenizeLayout splits a YYYY like layout into locale tokens and literal text, matching the longest token at each position
+func tokenizeLayout(inputLayout string) []layoutToken {
+	tokens := []layoutToken{}
+	for i := 0; i < len(inputLayout); {
+		matched := false
+		for _, c := range localeLayoutConversions {
+			if strings.HasPrefix(inputLayout[i:], c.token) {
+				tokens = append(tokens, layoutToken{token: c.token, layout: c.layout})
+				i += len(c.token)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		if n := len(tokens); n != 0 && tokens[n-1].token == "" {
+			tokens[n-1].literal += inputLayout[i : i+1]
+		} else {
+			tokens = append(tokens, layoutToken{literal: inputLayout[i : i+1]})
+		}
+		i++
+	}
+	return tokens
 }
 
 var layoutLengthMap = map[int][]string{}
@@ -37,8 +91,8 @@ func init() {
 
 //ConvertLayoutToGolangLayout converts a YYYY MM DD hh mm ss
 func convertLayoutToGolangLayout(inputLayout string) string {
-	for k, v := range layoutConversionMap {
-		inputLayout = strings.Replace(inputLayout, k, v, 1)
+	for _, c := range layoutConversions {
+		inputLayout = strings.Replace(inputLayout, c.token, c.layout, 1)
 	}
 	return inputLayout
 }
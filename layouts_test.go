@@ -29,3 +29,24 @@ func Test_smartDetectLayout(t *testing.T) {
 		})
 	}
 }
+
+func Test_convertLayoutToGolangLayout(t *testing.T) {
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{"YYYY-MM-DD hh:mm:ss", "2006-01-02 15:04:05"},
+		{"YYMMDD", "060102"},
+		//locale tokens are only read by FormatDatetimeInLocale and ParseDatetimeInLocale
+		{"YYYY-MM-DD At hh:mm", "2006-01-02 At 15:04"},
+		{"Day DD", "Day 02"},
+	}
+	for _, tt := range tests {
+		if got := convertLayoutToGolangLayout(tt.layout); got != tt.want {
+			t.Errorf("convertLayoutToGolangLayout(%q) = %q, want %q", tt.layout, got, tt.want)
+		}
+	}
+	if _, err := ParseDatetimeWithYYMMDDLikeLayout("2021-03-04 At 09:15", "YYYY-MM-DD At hh:mm"); err != nil {
+		t.Errorf("ParseDatetimeWithYYMMDDLikeLayout() with a literal A error = %v", err)
+	}
+}
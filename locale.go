@@ -0,0 +1,250 @@
+package datetime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+//Locale holds the month and weekday names, AM/PM markers and ordinal suffixes of a language
+//used by FormatDatetimeInLocale and ParseDatetimeInLocale for the MMMM, MMM, dddd, ddd, A and Do layout tokens
+type Locale struct {
+	Name        string
+	Months      [12]string
+	ShortMonths [12]string
+	//Weekdays start on Sunday, like time.Weekday
+	Weekdays      [7]string
+	ShortWeekdays [7]string
+	AM            string
+	PM            string
+	//Ordinal returns the suffix written after day, like "st" for 1 in English
+	Ordinal func(day int) string
+}
+
+//LocaleEnglish is the English Locale
+var LocaleEnglish = Locale{
+	Name:          "en",
+	Months:        [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	ShortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	Weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	ShortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	AM:            "AM",
+	PM:            "PM",
+	Ordinal: func(day int) string {
+		switch {
+		case day%100 >= 11 && day%100 <= 13:
+			return "th"
+		case day%10 == 1:
+			return "st"
+		case day%10 == 2:
+			return "nd"
+		case day%10 == 3:
+			return "rd"
+		}
+		return "th"
+	},
+}
+
+//LocaleGerman is the German Locale
+var LocaleGerman = Locale{
+	Name:          "de",
+	Months:        [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+	ShortMonths:   [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+	Weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	ShortWeekdays: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	AM:            "AM",
+	PM:            "PM",
+	Ordinal:       func(int) string { return "." },
+}
+
+//LocaleFrench is the French Locale
+var LocaleFrench = Locale{
+	Name:          "fr",
+	Months:        [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+	ShortMonths:   [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+	Weekdays:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	ShortWeekdays: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	AM:            "AM",
+	PM:            "PM",
+	Ordinal: func(day int) string {
+		if day == 1 {
+			return "er"
+		}
+		return "e"
+	},
+}
+
+//LocaleSpanish is the Spanish Locale
+var LocaleSpanish = Locale{
+	Name:          "es",
+	Months:        [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+	ShortMonths:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+	Weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	ShortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	AM:            "a. m.",
+	PM:            "p. m.",
+	Ordinal:       func(int) string { return "º" },
+}
+
+//LocaleHindi is the Hindi Locale
+var LocaleHindi = Locale{
+	Name:          "hi",
+	Months:        [12]string{"जनवरी", "फ़रवरी", "मार्च", "अप्रैल", "मई", "जून", "जुलाई", "अगस्त", "सितंबर", "अक्टूबर", "नवंबर", "दिसंबर"},
+	ShortMonths:   [12]string{"जन॰", "फ़र॰", "मार्च", "अप्रैल", "मई", "जून", "जुल॰", "अग॰", "सित॰", "अक्तू॰", "नव॰", "दिस॰"},
+	Weekdays:      [7]string{"रविवार", "सोमवार", "मंगलवार", "बुधवार", "गुरुवार", "शुक्रवार", "शनिवार"},
+	ShortWeekdays: [7]string{"रवि", "सोम", "मंगल", "बुध", "गुरु", "शुक्र", "शनि"},
+	AM:            "am",
+	PM:            "pm",
+	Ordinal:       func(int) string { return "" },
+}
+
+var locales = map[string]Locale{
+	"en": LocaleEnglish,
+	"de": LocaleGerman,
+	"fr": LocaleFrench,
+	"es": LocaleSpanish,
+	"hi": LocaleHindi,
+}
+
+//LocaleByName returns the built in Locale named like "de" or "de-DE"
+func LocaleByName(name string) (Locale, error) {
+	language := strings.ToLower(name)
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	locale, exists := locales[language]
+	if !exists {
+		return Locale{}, fmt.Errorf("(LocaleByName) no locale named %v", name)
+	}
+	return locale, nil
+}
+
+//FormatDatetimeInLocale formats t with a YYYY-MM-DD hh:mm:ss like layout, using names from locale
+//besides the tokens of ParseDatetimeWithYYMMDDLikeLayout, MMMM and MMM are month names, dddd and ddd weekday names,
+//A the AM/PM marker, II the 12 hour clock hour and Do the day of month with its ordinal suffix
+func FormatDatetimeInLocale(t time.Time, layout string, locale Locale) string {
+	var formatted strings.Builder
+	for _, token := range tokenizeLayout(layout) {
+		switch token.token {
+		case "":
+			formatted.WriteString(token.literal)
+		case "MMMM":
+			formatted.WriteString(locale.Months[t.Month()-1])
+		case "MMM":
+			formatted.WriteString(locale.ShortMonths[t.Month()-1])
+		case "dddd":
+			formatted.WriteString(locale.Weekdays[t.Weekday()])
+		case "ddd":
+			formatted.WriteString(locale.ShortWeekdays[t.Weekday()])
+		case "A":
+			if t.Hour() < 12 {
+				formatted.WriteString(locale.AM)
+			} else {
+				formatted.WriteString(locale.PM)
+			}
+		case "Do":
+			formatted.WriteString(strconv.Itoa(t.Day()))
+			if locale.Ordinal != nil {
+				formatted.WriteString(locale.Ordinal(t.Day()))
+			}
+		default:
+			formatted.WriteString(t.Format(token.layout))
+		}
+	}
+	return formatted.String()
+}
+
+//matchName returns the index of the longest of names datetime starts with, ignoring case, and its length in bytes
+func matchName(datetime string, names []string) (int, int) {
+	lower := strings.ToLower(datetime)
+	best, bestLen := -1, 0
+	for i, name := range names {
+		name = strings.ToLower(name)
+		if name != "" && len(name) > bestLen && strings.HasPrefix(lower, name) {
+			best, bestLen = i, len(name)
+		}
+	}
+	return best, bestLen
+}
+
+//ParseDatetimeInLocale parses datetime with a YYYY-MM-DD hh:mm:ss like layout, reading names from locale
+//it accepts the same tokens as FormatDatetimeInLocale, names are matched ignoring case and weekdays must match the date
+func ParseDatetimeInLocale(datetime string, layout string, locale Locale) (time.Time, error) {
+	//datetime is rewritten with english names so time.Parse can read it
+	var rewritten, goLayout strings.Builder
+	rest := datetime
+	weekday := -1
+	fail := func(token string) (time.Time, error) {
+		return time.Time{}, fmt.Errorf("(ParseDatetimeInLocale) cannot parse %q as %q in %v locale", rest, token, locale.Name)
+	}
+	for _, token := range tokenizeLayout(layout) {
+		var names, english []string
+		switch token.token {
+		case "":
+			if !strings.HasPrefix(rest, token.literal) {
+				return fail(token.literal)
+			}
+			rewritten.WriteString(token.literal)
+			goLayout.WriteString(token.literal)
+			rest = rest[len(token.literal):]
+			continue
+		case "MMMM":
+			names, english = locale.Months[:], LocaleEnglish.Months[:]
+		case "MMM":
+			names, english = locale.ShortMonths[:], LocaleEnglish.ShortMonths[:]
+		case "dddd":
+			names, english = locale.Weekdays[:], LocaleEnglish.Weekdays[:]
+		case "ddd":
+			names, english = locale.ShortWeekdays[:], LocaleEnglish.ShortWeekdays[:]
+		case "A":
+			names, english = []string{locale.AM, locale.PM}, []string{"AM", "PM"}
+		case "Do":
+			digits := len(rest) - len(strings.TrimLeftFunc(rest, unicode.IsDigit))
+			day, err := strconv.Atoi(rest[:digits])
+			if err != nil {
+				return fail(token.token)
+			}
+			rewritten.WriteString(rest[:digits])
+			goLayout.WriteString(token.layout)
+			rest = rest[digits:]
+			if locale.Ordinal != nil {
+				rest = strings.TrimPrefix(rest, locale.Ordinal(day))
+			}
+			continue
+		default:
+			//numbers and zones are copied for time.Parse to read, up to the next literal
+			width := len(rest) - len(strings.TrimLeftFunc(rest, func(r rune) bool { return unicode.IsDigit(r) || r == '+' || r == '-' }))
+			if max := len(token.layout); token.layout != "999999999" && width > max {
+				width = max
+			}
+			rewritten.WriteString(rest[:width])
+			goLayout.WriteString(token.layout)
+			rest = rest[width:]
+			continue
+		}
+		i, n := matchName(rest, names)
+		if i < 0 {
+			return fail(token.token)
+		}
+		if token.token == "dddd" || token.token == "ddd" {
+			weekday = i
+		}
+		rewritten.WriteString(english[i])
+		goLayout.WriteString(token.layout)
+		rest = rest[n:]
+	}
+	if rest != "" {
+		return time.Time{}, fmt.Errorf("(ParseDatetimeInLocale) extra text %q after parsing %q", rest, datetime)
+	}
+	t, err := time.Parse(goLayout.String(), rewritten.String())
+	if err != nil {
+		return t, err
+	}
+	//time.Parse checks that weekday names are valid, but not that they match the date
+	if weekday >= 0 && t.Weekday() != time.Weekday(weekday) {
+		return time.Time{}, fmt.Errorf("(ParseDatetimeInLocale) %q is a %v, not a %v", datetime, locale.Weekdays[t.Weekday()], locale.Weekdays[weekday])
+	}
+	return t, nil
+}
@@ -0,0 +1,106 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDatetimeInLocale(t *testing.T) {
+	sample := time.Date(2021, 3, 1, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name   string
+		layout string
+		locale Locale
+		want   string
+	}{
+		{"english", "dddd, Do MMMM YYYY", LocaleEnglish, "Monday, 1st March 2021"},
+		{"english short", "ddd DD MMM YY II:mm A", LocaleEnglish, "Mon 01 Mar 21 03:04 PM"},
+		{"german", "dddd, Do MMMM YYYY", LocaleGerman, "Montag, 1. März 2021"},
+		{"german short", "ddd DD. MMM YYYY hh:mm", LocaleGerman, "Mo 01. Mär 2021 15:04"},
+		{"french", "dddd Do MMMM YYYY", LocaleFrench, "lundi 1er mars 2021"},
+		{"spanish", "dddd, DD de MMMM de YYYY II:mm A", LocaleSpanish, "lunes, 01 de marzo de 2021 03:04 p. m."},
+		{"hindi", "dddd, DD MMMM YYYY", LocaleHindi, "सोमवार, 01 मार्च 2021"},
+		{"numeric only", "YYYY-MM-DD hh:mm:ss", LocaleGerman, "2021-03-01 15:04:05"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatDatetimeInLocale(sample, tt.layout, tt.locale); got != tt.want {
+				t.Errorf("FormatDatetimeInLocale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDatetimeInLocale(t *testing.T) {
+	tests := []struct {
+		name     string
+		datetime string
+		layout   string
+		locale   Locale
+		want     time.Time
+		wantErr  bool
+	}{
+		{"english", "Monday, 1st March 2021", "dddd, Do MMMM YYYY", LocaleEnglish, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"german", "Montag, 1. März 2021", "dddd, Do MMMM YYYY", LocaleGerman, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"german ignores case", "MONTAG, 1. MÄRZ 2021", "dddd, Do MMMM YYYY", LocaleGerman, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"french", "samedi 23 janv. 2021", "ddd DD MMM YYYY", LocaleFrench, time.Time{}, true},
+		{"french short", "sam. 23 janv. 2021", "ddd DD MMM YYYY", LocaleFrench, time.Date(2021, 1, 23, 0, 0, 0, 0, time.UTC), false},
+		{"spanish pm", "01 de marzo de 2021 03:04 p. m.", "DD de MMMM de YYYY II:mm A", LocaleSpanish, time.Date(2021, 3, 1, 15, 4, 0, 0, time.UTC), false},
+		{"hindi", "01 मार्च 2021", "DD MMMM YYYY", LocaleHindi, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"unknown month", "01 Brumaire 2021", "DD MMMM YYYY", LocaleFrench, time.Time{}, true},
+		{"wrong weekday", "Dienstag, 1. März 2021", "dddd, Do MMMM YYYY", LocaleGerman, time.Time{}, true},
+		{"trailing text", "01 März 2021 extra", "DD MMMM YYYY", LocaleGerman, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDatetimeInLocale(tt.datetime, tt.layout, tt.locale)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDatetimeInLocale() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseDatetimeInLocale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleRoundTrip(t *testing.T) {
+	layout := "dddd Do MMMM YYYY II:mm A"
+	for _, locale := range []Locale{LocaleEnglish, LocaleGerman, LocaleFrench, LocaleSpanish, LocaleHindi} {
+		for month := time.January; month <= time.December; month++ {
+			sample := time.Date(2021, month, int(month)*2, 9+int(month), 30, 0, 0, time.UTC)
+			formatted := FormatDatetimeInLocale(sample, layout, locale)
+			got, err := ParseDatetimeInLocale(formatted, layout, locale)
+			if err != nil || !got.Equal(sample) {
+				t.Errorf("%v: ParseDatetimeInLocale(%q) = %v, %v, want %v", locale.Name, formatted, got, err, sample)
+			}
+		}
+	}
+}
+
+func TestLocaleByName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"de", "de", false},
+		{"de-DE", "de", false},
+		{"FR_ca", "fr", false},
+		{"xx", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LocaleByName(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LocaleByName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Name != tt.want {
+				t.Errorf("LocaleByName() = %v, want %v", got.Name, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package datetime
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strings"
+	"time"
+)
+
+//DefaultNullTokens are the strings treated as missing values when no null tokens are given
+var DefaultNullTokens = []string{"", "NA", "null", "-"}
+
+//NullTime is a time which may be missing, unlike the zero time.Time returned by InlineParseDatetime which cannot be told apart from year 1
+type NullTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+//Bitmap is a fixed length set of bits, used as a validity mask with one bit per row
+type Bitmap struct {
+	words  []uint64
+	length int
+}
+
+//NewBitmap creates a Bitmap of length bits, all unset
+func NewBitmap(length int) Bitmap {
+	return Bitmap{words: make([]uint64, (length+63)/64), length: length}
+}
+
+//Len returns the number of bits
+func (b Bitmap) Len() int {
+	return b.length
+}
+
+//Get reports if bit i is set
+func (b Bitmap) Get(i int) bool {
+	return b.words[i/64]&(1<<uint(i%64)) != 0
+}
+
+//Set sets bit i to v. Bits in different words of 64 may be set concurrently
+func (b Bitmap) Set(i int, v bool) {
+	if v {
+		b.words[i/64] |= 1 << uint(i%64)
+	} else {
+		b.words[i/64] &^= 1 << uint(i%64)
+	}
+}
+
+//Count returns the number of set bits
+func (b Bitmap) Count() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+//TimeColumn is a column of times where some rows may be missing. Times[i] is the zero time when Valid.Get(i) is false
+type TimeColumn struct {
+	Times []time.Time
+	Valid Bitmap
+}
+
+//NewTimeColumn creates a TimeColumn from times, where every time is valid
+func NewTimeColumn(times []time.Time) TimeColumn {
+	c := TimeColumn{Times: times, Valid: NewBitmap(len(times))}
+	for i := range times {
+		c.Valid.Set(i, true)
+	}
+	return c
+}
+
+//Len returns the number of rows
+func (c TimeColumn) Len() int {
+	return len(c.Times)
+}
+
+//At returns row i as a NullTime
+func (c TimeColumn) At(i int) NullTime {
+	return NullTime{Time: c.Times[i], Valid: c.Valid.Get(i)}
+}
+
+//Nulls returns the number of missing rows
+func (c TimeColumn) Nulls() int {
+	return c.Len() - c.Valid.Count()
+}
+
+//DropNulls returns the valid times and the matching values, so they can be passed to functions like Upsample or RollingMean
+//values may be nil, in which case only times are returned
+func (c TimeColumn) DropNulls(values []float64) ([]time.Time, []float64) {
+	times := make([]time.Time, 0, c.Valid.Count())
+	var kept []float64
+	if values != nil {
+		kept = make([]float64, 0, c.Valid.Count())
+	}
+	for i := range c.Times {
+		if !c.Valid.Get(i) {
+			continue
+		}
+		times = append(times, c.Times[i])
+		if values != nil {
+			kept = append(kept, values[i])
+		}
+	}
+	return times, kept
+}
+
+func isNullToken(datetime string, nullTokens []string) bool {
+	datetime = strings.TrimSpace(datetime)
+	for _, token := range nullTokens {
+		if datetime == token {
+			return true
+		}
+	}
+	return false
+}
+
+//ParseNullDatetime is ParseDatetime where strings in nullTokens give an invalid NullTime rather than an error
+//if nullTokens are not provided, DefaultNullTokens are used
+func ParseNullDatetime(datetime string, nullTokens ...string) (NullTime, error) {
+	if nullTokens == nil {
+		nullTokens = DefaultNullTokens
+	}
+	if isNullToken(datetime, nullTokens) {
+		return NullTime{}, nil
+	}
+	t, err := ParseDatetime(datetime)
+	if err != nil {
+		return NullTime{}, err
+	}
+	return NullTime{Time: t, Valid: true}, nil
+}
+
+//ParseTimeColumn parses a column of datetime strings where strings in nullTokens are missing values, DefaultNullTokens if not provided
+//rows which fail to parse are marked invalid too, and reported together as RowErrors
+func ParseTimeColumn(datetimes []string, nullTokens ...string) (TimeColumn, error) {
+	if nullTokens == nil {
+		nullTokens = DefaultNullTokens
+	}
+	c := TimeColumn{Times: make([]time.Time, len(datetimes)), Valid: NewBitmap(len(datetimes))}
+	errs := RowErrors{}
+	for i, d := range datetimes {
+		t, err := ParseNullDatetime(d, nullTokens...)
+		if err != nil {
+			errs = append(errs, RowError{Row: i, Err: err})
+			continue
+		}
+		c.Times[i] = t.Time
+		c.Valid.Set(i, t.Valid)
+	}
+	if len(errs) != 0 {
+		return c, errs
+	}
+	return c, nil
+}
+
+//NullPolicy decides how missing rows affect aggregations
+type NullPolicy int
+
+const (
+	//NullSkip ignores missing rows
+	NullSkip NullPolicy = iota
+	//NullPropagate makes the result NaN wherever a missing row is involved
+	NullPropagate
+)
+
+//BucketTimeColumnByInterval is BucketIndexByInterval for a column with missing rows
+//buckets are made from valid times only, and missing rows are attached to the bucket of the valid row before them
+//(or the first bucket if they lead the column), so buckets still cover contiguous rows. Bucket.Nulls counts them
+func BucketTimeColumnByInterval(column TimeColumn, bucketingInterval time.Duration, emitEmpty bool, startTime ...time.Time) ([]Bucket, error) {
+	validTimes, _ := column.DropNulls(nil)
+	if len(validTimes) == 0 {
+		return []Bucket{}, fmt.Errorf("(BucketTimeColumnByInterval) cannot proceed as column has no valid times")
+	}
+	positions := make([]int, 0, len(validTimes))
+	for i := range column.Times {
+		if column.Valid.Get(i) {
+			positions = append(positions, i)
+		}
+	}
+	buckets, err := BucketIndexByInterval(validTimes, bucketingInterval, emitEmpty, startTime...)
+	if err != nil {
+		return buckets, err
+	}
+	for i := range buckets {
+		valid := buckets[i].Count
+		offset := 0
+		if i != 0 {
+			offset = positions[buckets[i].Offset]
+		}
+		end := column.Len()
+		if next := buckets[i].Offset + buckets[i].Count; next < len(positions) {
+			end = positions[next]
+		}
+		if valid == 0 {
+			offset, end = positions[buckets[i].Offset], positions[buckets[i].Offset]
+		}
+		buckets[i].Offset = offset
+		buckets[i].Count = end - offset
+		buckets[i].Nulls = buckets[i].Count - valid
+	}
+	return buckets, nil
+}
+
+//AggregateBuckets applies agg to the values of every bucket, skipping rows which are not set in valid
+//with NullPropagate, buckets with any missing row give NaN instead. valid may be a zero Bitmap if there are no missing rows
+func AggregateBuckets(buckets []Bucket, values []float64, valid Bitmap, agg func([]float64) float64, policy NullPolicy) ([]float64, error) {
+	if valid.Len() != 0 && valid.Len() != len(values) {
+		return nil, fmt.Errorf("(AggregateBuckets) failed because of length mismatch, validity has %v rows and values %v", valid.Len(), len(values))
+	}
+	out := make([]float64, len(buckets))
+	for i, b := range buckets {
+		if b.Offset+b.Count > len(values) {
+			return nil, fmt.Errorf("(AggregateBuckets) bucket %v is out of range for values of length %v", i, len(values))
+		}
+		window := b.Float64s(values)
+		if valid.Len() == 0 {
+			out[i] = agg(window)
+			continue
+		}
+		kept := make([]float64, 0, len(window))
+		for j := range window {
+			if valid.Get(b.Offset + j) {
+				kept = append(kept, window[j])
+			}
+		}
+		if policy == NullPropagate && len(kept) != len(window) {
+			out[i] = math.NaN()
+			continue
+		}
+		out[i] = agg(kept)
+	}
+	return out, nil
+}
@@ -0,0 +1,64 @@
+package datetime
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseTimeColumn(t *testing.T) {
+	column, err := ParseTimeColumn([]string{"2020-12-12 09:15:00", "NA", " ", "2020-12-12 09:20:00", "20 12-12"})
+	var rowErrs RowErrors
+	if !errors.As(err, &rowErrs) || len(rowErrs) != 1 || rowErrs[0].Row != 4 {
+		t.Errorf("ParseTimeColumn() error = %v, want error at row 4", err)
+	}
+	wantValid := []bool{true, false, false, true, false}
+	for i, want := range wantValid {
+		if column.Valid.Get(i) != want {
+			t.Errorf("ParseTimeColumn() row %v valid = %v, want %v", i, column.Valid.Get(i), want)
+		}
+	}
+	if column.Nulls() != 3 {
+		t.Errorf("TimeColumn.Nulls() = %v, want 3", column.Nulls())
+	}
+	if got, _ := ParseNullDatetime("missing", "missing"); got.Valid {
+		t.Errorf("ParseNullDatetime() with custom token = %v, want invalid", got)
+	}
+}
+
+func TestBucketTimeColumnByInterval(t *testing.T) {
+	column, _ := ParseTimeColumn([]string{"-", "2020-12-12 09:15:00", "-", "2020-12-12 09:17:00", "-", "2020-12-12 09:31:00"})
+	start := time.Date(2020, 12, 12, 9, 15, 0, 0, time.UTC)
+	buckets, err := BucketTimeColumnByInterval(column, time.Minute*5, true)
+	if err != nil {
+		t.Fatalf("BucketTimeColumnByInterval() error = %v", err)
+	}
+	want := []Bucket{
+		{start, start.Add(time.Minute * 5), 0, 5, 3},
+		{start.Add(time.Minute * 5), start.Add(time.Minute * 10), 5, 0, 0},
+		{start.Add(time.Minute * 10), start.Add(time.Minute * 15), 5, 0, 0},
+		{start.Add(time.Minute * 15), start.Add(time.Minute * 20), 5, 1, 0},
+	}
+	if !reflect.DeepEqual(buckets, want) {
+		t.Fatalf("BucketTimeColumnByInterval() = %v, want %v", buckets, want)
+	}
+
+	values := []float64{100, 1, 100, 3, 100, 5}
+	sum := func(v []float64) float64 {
+		total := 0.0
+		for _, x := range v {
+			total += x
+		}
+		return total
+	}
+	got, _ := AggregateBuckets(buckets, values, column.Valid, sum, NullSkip)
+	if !floatsEqual(got, []float64{4, 0, 0, 5}) {
+		t.Errorf("AggregateBuckets() skip = %v", got)
+	}
+	got, _ = AggregateBuckets(buckets, values, column.Valid, sum, NullPropagate)
+	if !floatsEqual(got, []float64{math.NaN(), 0, 0, 5}) {
+		t.Errorf("AggregateBuckets() propagate = %v", got)
+	}
+}
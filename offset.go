@@ -0,0 +1,318 @@
+package datetime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+//DateOffset is a rule picking dates from the calendar, like every month end or the 3rd Friday of every month
+//times keep their clock time and location, only the date moves
+type DateOffset interface {
+	//OnOffset reports if the date of t is picked by the offset
+	OnOffset(t time.Time) bool
+	//Next returns the first picked date after the date of t
+	Next(t time.Time) time.Time
+	//Prev returns the last picked date before the date of t
+	Prev(t time.Time) time.Time
+	//RollForward returns t if it is on the offset, otherwise Next
+	RollForward(t time.Time) time.Time
+	//RollBack returns t if it is on the offset, otherwise Prev
+	RollBack(t time.Time) time.Time
+}
+
+//AnchoredOffset picks one date in every period of some months, like the last day of every quarter
+//create one with MonthEndOffset, MonthBeginOffset, QuarterEndOffset, QuarterBeginOffset, YearEndOffset, YearBeginOffset, WeekOfMonthOffset, LastWeekdayOfMonthOffset,
+//BusinessMonthEndOffset, BusinessMonthBeginOffset or BusinessQuarterBeginOffset. It implements DateOffset
+type AnchoredOffset struct {
+	name string
+	//months is the length of the period, and anchor a month in which a period has its date
+	months int
+	anchor time.Month
+	//day returns the day of month picked in month, 0 if there is none
+	day func(year int, month time.Month) int
+}
+
+//maxOffsetPeriods bounds the search for a picked date, for offsets like the 5th Friday which skip months
+const maxOffsetPeriods = 120
+
+func isBusinessDay(year int, month time.Month, day int) bool {
+	weekday := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday()
+	return weekday != time.Saturday && weekday != time.Sunday
+}
+
+//MonthEndOffset picks the last day of every month
+func MonthEndOffset() AnchoredOffset {
+	return AnchoredOffset{name: "month end", months: 1, anchor: time.January, day: DaysInMonth}
+}
+
+//MonthBeginOffset picks the first day of every month
+func MonthBeginOffset() AnchoredOffset {
+	return AnchoredOffset{name: "month begin", months: 1, anchor: time.January, day: func(int, time.Month) int { return 1 }}
+}
+
+//QuarterEndOffset picks the last day of every quarter, quarters ending in March, June, September and December
+//unless endMonth is provided, like time.January for quarters ending in January, April, July and October
+func QuarterEndOffset(endMonth ...time.Month) AnchoredOffset {
+	anchor := time.March
+	if len(endMonth) != 0 {
+		anchor = endMonth[0]
+	}
+	return AnchoredOffset{name: "quarter end", months: 3, anchor: anchor, day: DaysInMonth}
+}
+
+//QuarterBeginOffset picks the first day of every quarter, quarters starting in January, April, July and October unless startMonth is provided
+func QuarterBeginOffset(startMonth ...time.Month) AnchoredOffset {
+	anchor := time.January
+	if len(startMonth) != 0 {
+		anchor = startMonth[0]
+	}
+	return AnchoredOffset{name: "quarter begin", months: 3, anchor: anchor, day: func(int, time.Month) int { return 1 }}
+}
+
+//YearEndOffset picks the last day of every year, December 31st unless endMonth is provided, like time.March for years ending March 31st
+func YearEndOffset(endMonth ...time.Month) AnchoredOffset {
+	anchor := time.December
+	if len(endMonth) != 0 {
+		anchor = endMonth[0]
+	}
+	return AnchoredOffset{name: "year end", months: 12, anchor: anchor, day: DaysInMonth}
+}
+
+//YearBeginOffset picks the first day of every year, January 1st unless startMonth is provided
+func YearBeginOffset(startMonth ...time.Month) AnchoredOffset {
+	anchor := time.January
+	if len(startMonth) != 0 {
+		anchor = startMonth[0]
+	}
+	return AnchoredOffset{name: "year begin", months: 12, anchor: anchor, day: func(int, time.Month) int { return 1 }}
+}
+
+//WeekOfMonthOffset picks the week-th weekday of every month, like WeekOfMonthOffset(3, time.Friday) for the 3rd Friday
+//week is 1 to 5, and months without a 5th weekday are skipped. an error is returned for other weeks or an invalid weekday
+func WeekOfMonthOffset(week int, weekday time.Weekday) (AnchoredOffset, error) {
+	if week < 1 || week > 5 {
+		return AnchoredOffset{}, fmt.Errorf("(WeekOfMonthOffset) week must be from 1 to 5, got %v", week)
+	}
+	if weekday < time.Sunday || weekday > time.Saturday {
+		return AnchoredOffset{}, fmt.Errorf("(WeekOfMonthOffset) invalid weekday %v", int(weekday))
+	}
+	return AnchoredOffset{
+		name:   fmt.Sprintf("week %v %v of month", week, weekday),
+		months: 1,
+		anchor: time.January,
+		day: func(year int, month time.Month) int {
+			first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+			day := 1 + (int(weekday)-int(first)+7)%7 + (week-1)*7
+			if day > DaysInMonth(year, month) {
+				return 0
+			}
+			return day
+		},
+	}, nil
+}
+
+//LastWeekdayOfMonthOffset picks the last weekday of every month, like the last Friday
+func LastWeekdayOfMonthOffset(weekday time.Weekday) AnchoredOffset {
+	return AnchoredOffset{
+		name:   fmt.Sprintf("last %v of month", weekday),
+		months: 1,
+		anchor: time.January,
+		day: func(year int, month time.Month) int {
+			last := DaysInMonth(year, month)
+			lastWeekday := time.Date(year, month, last, 0, 0, 0, 0, time.UTC).Weekday()
+			return last - (int(lastWeekday)-int(weekday)+7)%7
+		},
+	}
+}
+
+func lastBusinessDay(year int, month time.Month) int {
+	day := DaysInMonth(year, month)
+	for !isBusinessDay(year, month, day) {
+		day--
+	}
+	return day
+}
+
+func firstBusinessDay(year int, month time.Month) int {
+	day := 1
+	for !isBusinessDay(year, month, day) {
+		day++
+	}
+	return day
+}
+
+//BusinessMonthEndOffset picks the last Monday to Friday of every month
+func BusinessMonthEndOffset() AnchoredOffset {
+	return AnchoredOffset{name: "business month end", months: 1, anchor: time.January, day: lastBusinessDay}
+}
+
+//BusinessMonthBeginOffset picks the first Monday to Friday of every month
+func BusinessMonthBeginOffset() AnchoredOffset {
+	return AnchoredOffset{name: "business month begin", months: 1, anchor: time.January, day: firstBusinessDay}
+}
+
+//BusinessQuarterBeginOffset picks the first Monday to Friday of every quarter, quarters starting in January, April, July and October unless startMonth is provided
+func BusinessQuarterBeginOffset(startMonth ...time.Month) AnchoredOffset {
+	o := QuarterBeginOffset(startMonth...)
+	o.name, o.day = "business quarter begin", firstBusinessDay
+	return o
+}
+
+//String names the offset, like "month end"
+func (o AnchoredOffset) String() string {
+	return o.name
+}
+
+//period returns the months since year 0 of the last month holding a picked date at or before year and month
+func (o AnchoredOffset) period(year int, month time.Month) int {
+	months := year*12 + int(month) - 1
+	since := (months - int(o.anchor) + 1) % o.months
+	if since < 0 {
+		since += o.months
+	}
+	return months - since
+}
+
+//dateIn returns the date picked in the period starting months after year 0, 0 as day if there is none
+func (o AnchoredOffset) dateIn(months int) (int, time.Month, int) {
+	year, month := months/12, time.Month(months%12+1)
+	if months < 0 && months%12 != 0 {
+		year, month = months/12-1, time.Month(months%12+13)
+	}
+	return year, month, o.day(year, month)
+}
+
+//withDate moves t to year, month and day, keeping its clock time and location
+func withDate(t time.Time, year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+//compareDates compares the date of t with year, month and day, -1 if t is before
+func compareDates(t time.Time, year int, month time.Month, day int) int {
+	a := t.Year()*10000 + int(t.Month())*100 + t.Day()
+	b := year*10000 + int(month)*100 + day
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+//OnOffset reports if the date of t is picked by the offset
+func (o AnchoredOffset) OnOffset(t time.Time) bool {
+	year, month, day := o.dateIn(o.period(t.Year(), t.Month()))
+	return day != 0 && compareDates(t, year, month, day) == 0
+}
+
+//Next returns the first picked date after the date of t, at the clock time of t
+func (o AnchoredOffset) Next(t time.Time) time.Time {
+	months := o.period(t.Year(), t.Month())
+	for i := 0; i < maxOffsetPeriods; i, months = i+1, months+o.months {
+		if year, month, day := o.dateIn(months); day != 0 && compareDates(t, year, month, day) < 0 {
+			return withDate(t, year, month, day)
+		}
+	}
+	return time.Time{}
+}
+
+//Prev returns the last picked date before the date of t, at the clock time of t
+func (o AnchoredOffset) Prev(t time.Time) time.Time {
+	months := o.period(t.Year(), t.Month())
+	for i := 0; i < maxOffsetPeriods; i, months = i+1, months-o.months {
+		if year, month, day := o.dateIn(months); day != 0 && compareDates(t, year, month, day) > 0 {
+			return withDate(t, year, month, day)
+		}
+	}
+	return time.Time{}
+}
+
+//RollForward returns t if it is on the offset, otherwise Next
+func (o AnchoredOffset) RollForward(t time.Time) time.Time {
+	if o.OnOffset(t) {
+		return t
+	}
+	return o.Next(t)
+}
+
+//RollBack returns t if it is on the offset, otherwise Prev
+func (o AnchoredOffset) RollBack(t time.Time) time.Time {
+	if o.OnOffset(t) {
+		return t
+	}
+	return o.Prev(t)
+}
+
+//OffsetIterator lazily generates the dates picked by a DateOffset. It implements Iterator
+type OffsetIterator struct {
+	offset    DateOffset
+	start     time.Time
+	end       time.Time
+	bounded   bool
+	inclusive bool
+	length    int
+	count     int
+	current   time.Time
+}
+
+//NewOffsetIterator iterates the dates picked by offset from startTime, rolled forward, towards endTime
+//endTime is excluded unless inclusive is passed as true
+func NewOffsetIterator(offset DateOffset, startTime, endTime time.Time, inclusive ...bool) (*OffsetIterator, error) {
+	if endTime.Before(startTime) {
+		return nil, fmt.Errorf("(NewOffsetIterator) end time %v is before start time %v", endTime, startTime)
+	}
+	return &OffsetIterator{offset: offset, start: startTime, end: endTime, bounded: true, inclusive: inclusive != nil && inclusive[0]}, nil
+}
+
+//NewOffsetIteratorN iterates `length` dates picked by offset from startTime, rolled forward
+func NewOffsetIteratorN(offset DateOffset, startTime time.Time, length int) (*OffsetIterator, error) {
+	if length < 0 {
+		return nil, fmt.Errorf("(NewOffsetIteratorN) length must not be negative, got %v", length)
+	}
+	return &OffsetIterator{offset: offset, start: startTime, length: length}, nil
+}
+
+//Next advances the iterator, returning false when all dates have been generated
+func (it *OffsetIterator) Next() bool {
+	if !it.bounded && it.count >= it.length {
+		return false
+	}
+	next := it.offset.RollForward(it.start)
+	if it.count != 0 {
+		next = it.offset.Next(it.current)
+	}
+	if next.IsZero() {
+		return false
+	}
+	if it.bounded && (next.After(it.end) || (!it.inclusive && next.Equal(it.end))) {
+		return false
+	}
+	it.current = next
+	it.count++
+	return true
+}
+
+//Time returns the current date
+func (it *OffsetIterator) Time() time.Time {
+	return it.current
+}
+
+//Reset rewinds the iterator to its first date
+func (it *OffsetIterator) Reset() {
+	it.count = 0
+	it.current = time.Time{}
+}
+
+//GenerateOffsetRange creates the dates picked by offset between start and end times, end time excluded
+//like GenerateTimeRangeBetween, an empty range is returned if end time is before start time
+func GenerateOffsetRange(offset DateOffset, startTime, endTime time.Time) []time.Time {
+	it, err := NewOffsetIterator(offset, startTime, endTime)
+	if err != nil {
+		logrus.Errorln(err)
+		return []time.Time{}
+	}
+	return Collect(it)
+}
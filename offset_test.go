@@ -0,0 +1,111 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnchoredOffset(t *testing.T) {
+	//2021-02-10 is a Wednesday
+	mid := time.Date(2021, 2, 10, 9, 30, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name         string
+		offset       AnchoredOffset
+		wantForward  time.Time
+		wantBack     time.Time
+		wantOnOffset time.Time
+	}{
+		{"month end", MonthEndOffset(), date(2021, 2, 28), date(2021, 1, 31), date(2021, 2, 28)},
+		{"month begin", MonthBeginOffset(), date(2021, 3, 1), date(2021, 2, 1), date(2021, 2, 1)},
+		{"quarter end", QuarterEndOffset(), date(2021, 3, 31), date(2020, 12, 31), date(2021, 6, 30)},
+		{"quarter end in january", QuarterEndOffset(time.January), date(2021, 4, 30), date(2021, 1, 31), date(2021, 1, 31)},
+		{"quarter begin", QuarterBeginOffset(), date(2021, 4, 1), date(2021, 1, 1), date(2021, 7, 1)},
+		{"year end", YearEndOffset(), date(2021, 12, 31), date(2020, 12, 31), date(2021, 12, 31)},
+		{"fiscal year end", YearEndOffset(time.March), date(2021, 3, 31), date(2020, 3, 31), date(2022, 3, 31)},
+		{"year begin", YearBeginOffset(), date(2022, 1, 1), date(2021, 1, 1), date(2021, 1, 1)},
+		{"3rd friday", weekOfMonth(t, 3, time.Friday), date(2021, 2, 19), date(2021, 1, 15), date(2021, 3, 19)},
+		{"1st wednesday", weekOfMonth(t, 1, time.Wednesday), date(2021, 3, 3), date(2021, 2, 3), date(2021, 2, 3)},
+		{"5th monday skips months", weekOfMonth(t, 5, time.Monday), date(2021, 3, 29), date(2020, 11, 30), date(2021, 3, 29)},
+		{"last friday", LastWeekdayOfMonthOffset(time.Friday), date(2021, 2, 26), date(2021, 1, 29), date(2021, 4, 30)},
+		{"business month end", BusinessMonthEndOffset(), date(2021, 2, 26), date(2021, 1, 29), date(2021, 7, 30)},
+		{"business month begin", BusinessMonthBeginOffset(), date(2021, 3, 1), date(2021, 2, 1), date(2021, 5, 3)},
+		{"business quarter begin", BusinessQuarterBeginOffset(), date(2021, 4, 1), date(2021, 1, 1), date(2021, 1, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.offset.RollForward(mid); !got.Equal(tt.wantForward) {
+				t.Errorf("RollForward() = %v, want %v", got, tt.wantForward)
+			}
+			if got := tt.offset.RollBack(mid); !got.Equal(tt.wantBack) {
+				t.Errorf("RollBack() = %v, want %v", got, tt.wantBack)
+			}
+			if !tt.offset.OnOffset(tt.wantOnOffset) {
+				t.Errorf("OnOffset(%v) = false", tt.wantOnOffset)
+			}
+			if got := tt.offset.RollForward(tt.wantOnOffset); !got.Equal(tt.wantOnOffset) {
+				t.Errorf("RollForward() of a date on the offset = %v, want it unchanged", got)
+			}
+			if next := tt.offset.Next(tt.wantOnOffset); !next.After(tt.wantOnOffset) || !tt.offset.Prev(next).Equal(tt.wantOnOffset) {
+				t.Errorf("Next() = %v, and Prev() of it should be %v", next, tt.wantOnOffset)
+			}
+		})
+	}
+
+	for _, week := range []int{0, 6, -1} {
+		if _, err := WeekOfMonthOffset(week, time.Friday); err == nil {
+			t.Errorf("WeekOfMonthOffset(%v) should fail", week)
+		}
+	}
+	if _, err := WeekOfMonthOffset(1, time.Weekday(7)); err == nil {
+		t.Errorf("WeekOfMonthOffset() with weekday 7 should fail")
+	}
+}
+
+//weekOfMonth is WeekOfMonthOffset failing the test on an error
+func weekOfMonth(t *testing.T, week int, weekday time.Weekday) AnchoredOffset {
+	t.Helper()
+	offset, err := WeekOfMonthOffset(week, weekday)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return offset
+}
+
+func TestOffsetIterator(t *testing.T) {
+	start := time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)
+	got := GenerateOffsetRange(MonthEndOffset(), start, time.Date(2021, 4, 30, 0, 0, 0, 0, time.UTC))
+	want := []time.Time{
+		time.Date(2021, 1, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("GenerateOffsetRange() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("GenerateOffsetRange()[%v] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	it, _ := NewOffsetIterator(MonthEndOffset(), start, time.Date(2021, 4, 30, 0, 0, 0, 0, time.UTC), true)
+	if got := Collect(it); len(got) != 4 {
+		t.Errorf("NewOffsetIterator() inclusive got %v dates, want 4", len(got))
+	}
+	it, _ = NewOffsetIteratorN(weekOfMonth(t, 3, time.Friday), start, 12)
+	thirdFridays := Collect(it)
+	if len(thirdFridays) != 12 || !thirdFridays[0].Equal(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("NewOffsetIteratorN() = %v, want 12 third Fridays from 2021-01-15", thirdFridays)
+	}
+	for _, d := range thirdFridays {
+		if d.Weekday() != time.Friday || d.Day() < 15 || d.Day() > 21 {
+			t.Errorf("NewOffsetIteratorN() gave %v, not a third Friday", d)
+		}
+	}
+	if got := GenerateOffsetRange(MonthEndOffset(), start, start.AddDate(0, 0, -1)); len(got) != 0 {
+		t.Errorf("GenerateOffsetRange() with end before start = %v, want empty", got)
+	}
+}
@@ -0,0 +1,145 @@
+package datetime
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//parseChunkSize is the number of rows a worker parses at a time
+const parseChunkSize = 1024
+
+//RowError is the error of parsing a single row of a column
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("row %v: %v", e.Row, e.Err)
+}
+
+//Unwrap returns the underlying parsing error
+func (e RowError) Unwrap() error {
+	return e.Err
+}
+
+//RowErrors holds the errors of every row which failed to parse, ordered by row
+type RowErrors []RowError
+
+func (e RowErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%v rows failed to parse: %v", len(e), strings.Join(msgs, "; "))
+}
+
+//ParseResult is a parsed column of datetimes. Rows which are null, failed to parse or were not parsed are not set in Valid
+type ParseResult struct {
+	TimeColumn
+	Errors RowErrors
+}
+
+//ParallelParseOptions configures ParseDatetimeArrayParallel
+type ParallelParseOptions struct {
+	//Workers is the number of goroutines parsing, if not positive runtime.GOMAXPROCS(0) is used
+	Workers int
+	//CollectErrors parses every row instead of stopping at the first error
+	CollectErrors bool
+	//NullTokens are strings giving missing rows instead of errors, if nil every row must parse
+	NullTokens []string
+}
+
+//ParseDatetimeArrayParallel is ParseDatetimeArray using several goroutines, for large columns
+//output order always matches input order. By default parsing stops at the first failing row, which is returned as a RowError,
+//and is always the lowest failing row so results are deterministic
+//with CollectErrors every row is parsed, and the returned error is a RowErrors holding every failure, also found in the result
+//cancelling ctx stops parsing and returns ctx.Err()
+func ParseDatetimeArrayParallel(ctx context.Context, datetimes []string, opts ...ParallelParseOptions) (ParseResult, error) {
+	var opt ParallelParseOptions
+	if opts != nil {
+		opt = opts[0]
+	}
+	workers := opt.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	result := ParseResult{TimeColumn: TimeColumn{
+		Times: make([]time.Time, len(datetimes)),
+		Valid: NewBitmap(len(datetimes)),
+	}}
+
+	var mu sync.Mutex
+	//firstErrRow is the lowest failing row so far, rows after it need not be parsed unless collecting errors
+	firstErrRow := int64(len(datetimes))
+	chunks := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range chunks {
+				end := start + parseChunkSize
+				if end > len(datetimes) {
+					end = len(datetimes)
+				}
+				for row := start; row < end; row++ {
+					if !opt.CollectErrors && int64(row) > atomic.LoadInt64(&firstErrRow) {
+						break
+					}
+					if opt.NullTokens != nil && isNullToken(datetimes[row], opt.NullTokens) {
+						continue
+					}
+					t, err := ParseDatetime(datetimes[row])
+					if err != nil {
+						mu.Lock()
+						result.Errors = append(result.Errors, RowError{Row: row, Err: err})
+						if int64(row) < atomic.LoadInt64(&firstErrRow) {
+							atomic.StoreInt64(&firstErrRow, int64(row))
+						}
+						mu.Unlock()
+						continue
+					}
+					result.Times[row] = t
+					//chunks are a multiple of 64 rows, so workers never set bits of the same word
+					result.Valid.Set(row, true)
+				}
+			}
+		}()
+	}
+
+	var ctxErr error
+feed:
+	for start := 0; start < len(datetimes); start += parseChunkSize {
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			ctxErr = ctx.Err()
+			break feed
+		case chunks <- start:
+		}
+	}
+	close(chunks)
+	wg.Wait()
+
+	sort.Slice(result.Errors, func(i, j int) bool { return result.Errors[i].Row < result.Errors[j].Row })
+	if ctxErr != nil {
+		return result, ctxErr
+	}
+	if len(result.Errors) == 0 {
+		return result, nil
+	}
+	if opt.CollectErrors {
+		return result, result.Errors
+	}
+	result.Errors = result.Errors[:1]
+	return result, result.Errors[0]
+}
@@ -0,0 +1,52 @@
+package datetime
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseDatetimeArrayParallel(t *testing.T) {
+	valid := []string{}
+	for i := 0; i < 3000; i++ {
+		valid = append(valid, times[i%len(times)])
+	}
+	want, _ := ParseDatetimeArray(valid)
+	withErrors := append(append([]string{}, valid...), invalidSamples[2])
+	withErrors[1500] = invalidSamples[0][:4] + "x"
+
+	t.Run("valid rows keep order", func(t *testing.T) {
+		got, err := ParseDatetimeArrayParallel(context.Background(), valid, ParallelParseOptions{Workers: 4})
+		if err != nil {
+			t.Fatalf("ParseDatetimeArrayParallel() error = %v", err)
+		}
+		if !reflect.DeepEqual(got.Times, want) {
+			t.Errorf("ParseDatetimeArrayParallel() times do not match ParseDatetimeArray")
+		}
+	})
+	t.Run("stops at first error", func(t *testing.T) {
+		_, err := ParseDatetimeArrayParallel(context.Background(), withErrors, ParallelParseOptions{Workers: 4})
+		var rowErr RowError
+		if !errors.As(err, &rowErr) || rowErr.Row != 1500 {
+			t.Errorf("ParseDatetimeArrayParallel() error = %v, want error at row 1500", err)
+		}
+	})
+	t.Run("collects all errors", func(t *testing.T) {
+		got, err := ParseDatetimeArrayParallel(context.Background(), withErrors, ParallelParseOptions{Workers: 4, CollectErrors: true})
+		var rowErrs RowErrors
+		if !errors.As(err, &rowErrs) || len(rowErrs) != 2 || rowErrs[0].Row != 1500 || rowErrs[1].Row != 3000 {
+			t.Fatalf("ParseDatetimeArrayParallel() error = %v, want errors at rows 1500 and 3000", err)
+		}
+		if got.Valid.Get(1500) || got.Valid.Get(3000) || !got.Valid.Get(1499) || !got.Times[2999].Equal(want[2999]) {
+			t.Errorf("ParseDatetimeArrayParallel() validity mask does not match errors")
+		}
+	})
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := ParseDatetimeArrayParallel(ctx, valid); err != context.Canceled {
+			t.Errorf("ParseDatetimeArrayParallel() error = %v, want context.Canceled", err)
+		}
+	})
+}
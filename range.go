@@ -0,0 +1,86 @@
+package datetime
+
+import "time"
+
+//Times are compared with their monotonic clock readings stripped, using Round(0), so times from time.Now
+//order the same as times parsed or built with time.Date. The times returned are the ones passed in, unchanged
+
+//MinMax returns the earliest and latest times in ts and their positions, the first position on ties
+//ok is false if ts is empty, in which case the times are zero and the positions -1
+func MinMax(ts []time.Time) (min, max time.Time, minIdx, maxIdx int, ok bool) {
+	if len(ts) == 0 {
+		return time.Time{}, time.Time{}, -1, -1, false
+	}
+	minIdx, maxIdx = 0, 0
+	lo, hi := ts[0].Round(0), ts[0].Round(0)
+	for i := 1; i < len(ts); i++ {
+		t := ts[i].Round(0)
+		if t.Before(lo) {
+			lo, minIdx = t, i
+		}
+		if t.After(hi) {
+			hi, maxIdx = t, i
+		}
+	}
+	return ts[minIdx], ts[maxIdx], minIdx, maxIdx, true
+}
+
+//ArgMin returns the position of the earliest time in ts, the first on ties, or -1 if ts is empty
+func ArgMin(ts []time.Time) int {
+	_, _, minIdx, _, _ := MinMax(ts)
+	return minIdx
+}
+
+//ArgMax returns the position of the latest time in ts, the first on ties, or -1 if ts is empty
+func ArgMax(ts []time.Time) int {
+	_, _, _, maxIdx, _ := MinMax(ts)
+	return maxIdx
+}
+
+//Clamp returns lo if t is before lo, hi if t is after hi, and t otherwise. lo and hi are swapped if hi is before lo
+func Clamp(t, lo, hi time.Time) time.Time {
+	if hi.Round(0).Before(lo.Round(0)) {
+		lo, hi = hi, lo
+	}
+	switch {
+	case t.Round(0).Before(lo.Round(0)):
+		return lo
+	case t.Round(0).After(hi.Round(0)):
+		return hi
+	}
+	return t
+}
+
+//Range is the span of time from Start to End
+type Range struct {
+	Start time.Time
+	End   time.Time
+}
+
+//Contains reports if t is in [Start, End), like DatetimeIsInRange
+func (r Range) Contains(t time.Time) bool {
+	t = t.Round(0)
+	return !t.Before(r.Start.Round(0)) && t.Before(r.End.Round(0))
+}
+
+//Covers reports if t is in [Start, End], including End, as needed for the Range returned by Span
+func (r Range) Covers(t time.Time) bool {
+	return r.Contains(t) || t.Round(0).Equal(r.End.Round(0))
+}
+
+//Duration returns the length of the range, negative if End is before Start
+func (r Range) Duration() time.Duration {
+	return r.End.Round(0).Sub(r.Start.Round(0))
+}
+
+//IsEmpty reports if the range contains no time, as End is not after Start
+func (r Range) IsEmpty() bool {
+	return !r.End.Round(0).After(r.Start.Round(0))
+}
+
+//Span returns the Range from the earliest to the latest time in ts, so End is the latest time itself, see Range.Covers
+//ok is false if ts is empty
+func Span(ts []time.Time) (Range, bool) {
+	min, max, _, _, ok := MinMax(ts)
+	return Range{Start: min, End: max}, ok
+}
@@ -0,0 +1,92 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMinMax(t *testing.T) {
+	base := time.Date(2021, 3, 4, 9, 15, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		ts      []time.Time
+		wantMin int
+		wantMax int
+		wantOk  bool
+	}{
+		{"empty", nil, -1, -1, false},
+		{"single", []time.Time{base}, 0, 0, true},
+		{"unsorted", []time.Time{base, base.Add(-time.Hour), base.Add(time.Hour), base}, 1, 2, true},
+		{"ties take first", []time.Time{base, base, base.In(time.FixedZone("+05:30", 19800))}, 0, 0, true},
+		{"after 2099", []time.Time{time.Date(2150, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2120, 1, 1, 0, 0, 0, 0, time.UTC)}, 1, 0, true},
+		{"before year 1", []time.Time{time.Date(-5, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(-10, 1, 1, 0, 0, 0, 0, time.UTC)}, 1, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, max, minIdx, maxIdx, ok := MinMax(tt.ts)
+			if minIdx != tt.wantMin || maxIdx != tt.wantMax || ok != tt.wantOk {
+				t.Errorf("MinMax() positions = %v, %v, %v, want %v, %v, %v", minIdx, maxIdx, ok, tt.wantMin, tt.wantMax, tt.wantOk)
+				return
+			}
+			if ok && (!min.Equal(tt.ts[minIdx]) || !max.Equal(tt.ts[maxIdx])) {
+				t.Errorf("MinMax() = %v, %v, want the times at the positions", min, max)
+			}
+			if ArgMin(tt.ts) != tt.wantMin || ArgMax(tt.ts) != tt.wantMax {
+				t.Errorf("ArgMin(), ArgMax() = %v, %v, want %v, %v", ArgMin(tt.ts), ArgMax(tt.ts), tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestMinMaxMonotonic(t *testing.T) {
+	now := time.Now()
+	//now carries a monotonic reading, parsed has none, but they are the same wall clock instant
+	parsed := now.Round(0)
+	earlier := parsed.Add(-time.Second)
+	_, max, minIdx, _, _ := MinMax([]time.Time{now, earlier, parsed})
+	if minIdx != 1 || max != now {
+		t.Errorf("MinMax() = %v, %v, want the earlier time first and now returned unchanged", minIdx, max)
+	}
+}
+
+func TestClamp(t *testing.T) {
+	lo := time.Date(2021, 3, 4, 9, 0, 0, 0, time.UTC)
+	hi := lo.Add(time.Hour)
+	tests := []struct {
+		name string
+		t    time.Time
+		lo   time.Time
+		hi   time.Time
+		want time.Time
+	}{
+		{"inside", lo.Add(time.Minute), lo, hi, lo.Add(time.Minute)},
+		{"before", lo.Add(-time.Minute), lo, hi, lo},
+		{"after", hi.Add(time.Minute), lo, hi, hi},
+		{"swapped bounds", hi.Add(time.Minute), hi, lo, hi},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Clamp(tt.t, tt.lo, tt.hi); !got.Equal(tt.want) {
+				t.Errorf("Clamp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpan(t *testing.T) {
+	base := time.Date(2021, 3, 4, 9, 0, 0, 0, time.UTC)
+	ts := []time.Time{base.Add(time.Hour), base, base.Add(time.Minute * 30)}
+	r, ok := Span(ts)
+	if !ok || !r.Start.Equal(base) || !r.End.Equal(base.Add(time.Hour)) || r.Duration() != time.Hour {
+		t.Errorf("Span() = %+v, %v, want an hour from %v", r, ok, base)
+	}
+	if r.Contains(base.Add(time.Hour)) || !r.Covers(base.Add(time.Hour)) || !r.Contains(base) {
+		t.Errorf("Range.Contains() should exclude End and Range.Covers() include it")
+	}
+	if r.IsEmpty() {
+		t.Errorf("Range.IsEmpty() = true for %+v", r)
+	}
+	if r, ok := Span(nil); ok || !r.IsEmpty() {
+		t.Errorf("Span(nil) = %+v, %v, want an empty range", r, ok)
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"reflect"
 	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
 //BucketTimeArrayByInterval buckets time array by interval provided, ie it starts at first sample and splits into arrays each of the duration supplied
 //if startTime is provided, that is used as reference to start splitting
 //this is useful if you have a struct which is indexed by time, splitting data columns by length of arrays returned
+//index must be sorted, an error is returned otherwise
 func BucketTimeArrayByInterval(index []time.Time, bucketingInterval time.Duration, startTime ...time.Time) ([][]time.Time, error) {
 	var startAtTime time.Time
 	var bucketedTimes [][]time.Time
@@ -16,6 +19,12 @@ func BucketTimeArrayByInterval(index []time.Time, bucketingInterval time.Duratio
 	if len(index) == 0 {
 		return bucketedTimes, fmt.Errorf("(BucketArrayByInterval) cannot proceed as length of time array is 0")
 	}
+	if bucketingInterval <= 0 {
+		return bucketedTimes, fmt.Errorf("(BucketArrayByInterval) bucketing interval must be positive, got %v", bucketingInterval)
+	}
+	if err := checkSorted("BucketArrayByInterval", "index", index); err != nil {
+		return bucketedTimes, err
+	}
 	if startTime != nil {
 		startAtTime = startTime[0]
 	} else {
@@ -42,6 +51,144 @@ func BucketTimeArrayByInterval(index []time.Time, bucketingInterval time.Duratio
 	return bucketedTimes, nil
 }
 
+//Bucket is the window [Start, End) of one bucket, and the samples of the index falling in it
+//samples are the positions Offset to Offset+Count-1 of the bucketed index, so data columns can be sliced with them directly
+//Nulls is the number of those rows with a missing time, see BucketTimeColumnByInterval
+type Bucket struct {
+	Start  time.Time
+	End    time.Time
+	Offset int
+	Count  int
+	Nulls  int
+}
+
+//Indices returns the positions in the bucketed index of the samples in this bucket
+func (b Bucket) Indices() []int {
+	indices := make([]int, b.Count)
+	for i := range indices {
+		indices[i] = b.Offset + i
+	}
+	return indices
+}
+
+//Times returns the part of index in this bucket, without copying
+func (b Bucket) Times(index []time.Time) []time.Time {
+	return index[b.Offset : b.Offset+b.Count]
+}
+
+//Float64s returns the part of data in this bucket, without copying
+func (b Bucket) Float64s(data []float64) []float64 {
+	return data[b.Offset : b.Offset+b.Count]
+}
+
+//BucketIndexByInterval splits index into windows of bucketingInterval like BucketTimeArrayByInterval,
+//but returns each window with its boundaries and the positions of its samples instead of copies of the times
+//if emitEmpty is true, windows without samples are returned too, with a Count of 0
+//if startTime is provided, that is used as reference to start splitting, and must not be after the first sample
+//index must be sorted, an error is returned otherwise
+func BucketIndexByInterval(index []time.Time, bucketingInterval time.Duration, emitEmpty bool, startTime ...time.Time) ([]Bucket, error) {
+	buckets := []Bucket{}
+	if len(index) == 0 {
+		return buckets, fmt.Errorf("(BucketIndexByInterval) cannot proceed as length of time array is 0")
+	}
+	if bucketingInterval <= 0 {
+		return buckets, fmt.Errorf("(BucketIndexByInterval) bucketing interval must be positive, got %v", bucketingInterval)
+	}
+	if err := checkSorted("BucketIndexByInterval", "index", index); err != nil {
+		return buckets, err
+	}
+	startAtTime := index[0]
+	if len(startTime) != 0 {
+		startAtTime = startTime[0]
+	}
+	if index[0].Before(startAtTime) {
+		return buckets, fmt.Errorf("(BucketIndexByInterval) start time %v is after first sample %v", startAtTime, index[0])
+	}
+
+	bucketAt := func(k int64) Bucket {
+		start := startAtTime.Add(time.Duration(k) * bucketingInterval)
+		return Bucket{Start: start, End: start.Add(bucketingInterval)}
+	}
+	presentK := int64(index[0].Sub(startAtTime) / bucketingInterval)
+	present := bucketAt(presentK)
+	for i := range index {
+		k := int64(index[i].Sub(startAtTime) / bucketingInterval)
+		if k != presentK {
+			buckets = append(buckets, present)
+			if emitEmpty {
+				for empty := presentK + 1; empty < k; empty++ {
+					b := bucketAt(empty)
+					b.Offset = i
+					buckets = append(buckets, b)
+				}
+			}
+			present = bucketAt(k)
+			present.Offset = i
+			presentK = k
+		}
+		present.Count++
+	}
+	return append(buckets, present), nil
+}
+
+//BucketIndexByWallClock is BucketIndexByInterval where intervals of a day or longer keep the local clock time in loc,
+//so daily buckets start at midnight and are 23 or 25 hours long across daylight saving changes. see NewWallClockTimeIterator
+//shorter intervals are elapsed time, so after clocks go forward 2h buckets from midnight start at 03:00, 05:00 and so on
+//if startTime is not provided, the first sample in loc is used
+func BucketIndexByWallClock(index []time.Time, bucketingInterval time.Duration, loc *time.Location, emitEmpty bool, startTime ...time.Time) ([]Bucket, error) {
+	buckets := []Bucket{}
+	if len(index) == 0 {
+		return buckets, fmt.Errorf("(BucketIndexByWallClock) cannot proceed as length of time array is 0")
+	}
+	if bucketingInterval <= 0 {
+		return buckets, fmt.Errorf("(BucketIndexByWallClock) bucketing interval must be positive, got %v", bucketingInterval)
+	}
+	if err := checkSorted("BucketIndexByWallClock", "index", index); err != nil {
+		return buckets, err
+	}
+	startAtTime := index[0].In(loc)
+	if len(startTime) != 0 {
+		startAtTime = startTime[0].In(loc)
+	}
+	if index[0].Before(startAtTime) {
+		return buckets, fmt.Errorf("(BucketIndexByWallClock) start time %v is after first sample %v", startAtTime, index[0])
+	}
+
+	boundaries, _ := NewWallClockTimeIteratorN(startAtTime, bucketingInterval, 0, DSTCompatible)
+	//locate returns the bucket holding t, estimated from elapsed time, or calendar days for intervals of a day or more,
+	//and corrected by the few hours daylight saving can move a boundary
+	locate := func(t time.Time) int {
+		k := int(t.Sub(startAtTime) / bucketingInterval)
+		if bucketingInterval >= DurationDay() {
+			k = int(time.Duration(calendarDays(startAtTime, t.In(loc))) * DurationDay() / bucketingInterval)
+		}
+		for k > 0 && t.Before(boundaries.At(k)) {
+			k--
+		}
+		for !t.Before(boundaries.At(k + 1)) {
+			k++
+		}
+		return k
+	}
+	k := locate(index[0])
+	present := Bucket{Start: boundaries.At(k), End: boundaries.At(k + 1)}
+	for i := range index {
+		if !index[i].Before(present.End) {
+			buckets = append(buckets, present)
+			next := locate(index[i])
+			if emitEmpty {
+				for k++; k < next; k++ {
+					buckets = append(buckets, Bucket{Start: boundaries.At(k), End: boundaries.At(k + 1), Offset: i})
+				}
+			}
+			k = next
+			present = Bucket{Start: boundaries.At(k), End: boundaries.At(k + 1), Offset: i}
+		}
+		present.Count++
+	}
+	return append(buckets, present), nil
+}
+
 //BucketDataSliceByBucketedTimeArray converts data of any array type to multi row array each of lengths matching bucketedTimes
 //error if total lengths dont match
 //After recieving output, convert to to type using  output.([][]Typename)
@@ -60,7 +207,7 @@ func BucketDataSliceByBucketedTimeArray(bucketedTimes [][]time.Time, data interf
 	if totalLen != dataValue.Len() {
 		return bucketedData.Interface(), fmt.Errorf("(BucketFloat64DataByBucketedTimeArray)failed because of length mismatch")
 	}
-	return bucketedData, nil
+	return bucketedData.Interface(), nil
 }
 
 //BucketFloat64SliceByBucketedTimeArray converts data to multi row array corresponding to lengths of bucketedTimes provided
@@ -78,21 +225,28 @@ func BucketFloat64SliceByBucketedTimeArray(bucketedTimes [][]time.Time, data []f
 }
 
 //GenerateTimeRange creates a range of times by adding interval to startTime `length` number of times
+//if interval is 0 an empty range is returned. for long ranges use NewTimeIteratorN, which does not allocate the whole range
 func GenerateTimeRange(startTime time.Time, interval time.Duration, length int) []time.Time {
-	t := []time.Time{}
-	for i := 0; i < length; i++ {
-		t = append(t, startTime)
-		startTime = startTime.Add(interval)
+	it, err := NewTimeIteratorN(startTime, interval, length)
+	if err != nil {
+		logrus.Errorln(err)
+		return []time.Time{}
 	}
-	return t
+	return Collect(it)
 }
 
-//GenerateTimeRangeBetween creates a range of times of size interval between start and end times
+//GenerateTimeRangeBetween creates a range of times of size interval between start and end times, end time excluded
+//if interval is not positive, or end time is not after start time, an empty range is returned
+//for descending ranges or long ranges use NewTimeIterator, which takes negative intervals and does not allocate the whole range
 func GenerateTimeRangeBetween(startTime time.Time, endTime time.Time, interval time.Duration) []time.Time {
-	t := []time.Time{}
-	for endTime.After(startTime) {
-		t = append(t, startTime)
-		startTime = startTime.Add(interval)
+	if interval <= 0 {
+		logrus.Errorln(fmt.Errorf("(GenerateTimeRangeBetween) interval must be positive, got %v", interval))
+		return []time.Time{}
+	}
+	it, err := NewTimeIterator(startTime, endTime, interval)
+	if err != nil {
+		logrus.Errorln(err)
+		return []time.Time{}
 	}
-	return t
+	return Collect(it)
 }
@@ -68,3 +68,133 @@ func TestBucketDataSliceByBucketedTimeArray(t *testing.T) {
 		})
 	}
 }
+
+func TestBucketIndexByInterval(t *testing.T) {
+	parsedTimes, _ := ParseDatetimeArray(times)
+	start := parsedTimes[0]
+	type args struct {
+		index             []time.Time
+		bucketingInterval time.Duration
+		emitEmpty         bool
+		startTime         []time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []Bucket
+		wantErr bool
+	}{
+		{"no offset 5 mins", args{parsedTimes[:3], time.Minute * 5, false, nil}, []Bucket{
+			{start, start.Add(time.Minute * 5), 0, 2, 0},
+			{start.Add(time.Minute * 5), start.Add(time.Minute * 10), 2, 1, 0},
+		}, false},
+		{"empty buckets 10 mins", args{parsedTimes[:5], time.Minute * 10, true, nil}, []Bucket{
+			{start, start.Add(time.Minute * 10), 0, 3, 0},
+			{start.Add(time.Minute * 10), start.Add(time.Minute * 20), 3, 0, 0},
+			{start.Add(time.Minute * 20), start.Add(time.Minute * 30), 3, 2, 0},
+		}, false},
+		{"day start offset", args{parsedTimes, time.Hour * 24, false, []time.Time{ExtractDateFromDatetime(start)}}, []Bucket{
+			{ExtractDateFromDatetime(start), ExtractDateFromDatetime(start).Add(time.Hour * 24), 0, 5, 0},
+			{ExtractDateFromDatetime(start).Add(time.Hour * 24), ExtractDateFromDatetime(start).Add(time.Hour * 48), 5, 1, 0},
+		}, false},
+		{"start after first sample", args{parsedTimes, time.Minute, false, []time.Time{start.Add(time.Minute)}}, []Bucket{}, true},
+		{"zero interval", args{parsedTimes, 0, false, nil}, []Bucket{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BucketIndexByInterval(tt.args.index, tt.args.bucketingInterval, tt.args.emitEmpty, tt.args.startTime...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BucketIndexByInterval() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BucketIndexByInterval() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBucketTimeArrayByIntervalUnsorted(t *testing.T) {
+	parsedTimes, _ := ParseDatetimeArray([]string{times[1], times[0]})
+	if _, err := BucketTimeArrayByInterval(parsedTimes, time.Minute); err == nil {
+		t.Errorf("BucketTimeArrayByInterval() expected error for unsorted index")
+	}
+}
+
+func TestBucketIndexByWallClock(t *testing.T) {
+	loc := newYork(t)
+	start := time.Date(2021, 3, 13, 0, 0, 0, 0, loc)
+	index := GenerateTimeRange(start, time.Hour*6, 12)
+	buckets, err := BucketIndexByWallClock(index, DurationDay(), loc, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCounts := []int{4, 4, 4}
+	if len(buckets) != len(wantCounts) {
+		t.Fatalf("BucketIndexByWallClock() got %v buckets, want %v", len(buckets), len(wantCounts))
+	}
+	for i, b := range buckets {
+		if b.Start.Hour() != 0 || b.End.Hour() != 0 {
+			t.Errorf("BucketIndexByWallClock() bucket %v = [%v, %v), want midnight boundaries", i, b.Start, b.End)
+		}
+		if b.Count != wantCounts[i] {
+			t.Errorf("BucketIndexByWallClock() bucket %v has %v samples, want %v", i, b.Count, wantCounts[i])
+		}
+	}
+	if got := buckets[1].End.Sub(buckets[1].Start); got != time.Hour*23 {
+		t.Errorf("BucketIndexByWallClock() day of the change lasts %v, want 23h", got)
+	}
+	if _, err := BucketIndexByWallClock(index, 0, loc, false); err == nil {
+		t.Errorf("BucketIndexByWallClock() with zero interval should fail")
+	}
+
+	//hourly buckets across both changes are all one hour long
+	for _, day := range []time.Time{time.Date(2021, 3, 14, 0, 0, 0, 0, loc), time.Date(2021, 11, 7, 0, 0, 0, 0, loc)} {
+		index := GenerateTimeRange(day, time.Minute*30, 50)
+		buckets, err := BucketIndexByWallClock(index, time.Hour, loc, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(buckets) != 25 {
+			t.Errorf("BucketIndexByWallClock() hourly from %v got %v buckets, want 25", day, len(buckets))
+		}
+		for i, b := range buckets {
+			if b.End.Sub(b.Start) != time.Hour || b.Count != 2 {
+				t.Errorf("BucketIndexByWallClock() hourly bucket %v = [%v, %v) with %v samples, want 1h with 2", i, b.Start, b.End, b.Count)
+			}
+		}
+	}
+
+	//sub-day buckets are elapsed time, so 2h buckets move to odd hours once clocks go forward
+	index = GenerateTimeRange(time.Date(2021, 3, 14, 0, 0, 0, 0, loc), time.Hour, 6)
+	buckets, err = BucketIndexByWallClock(index, time.Hour*2, loc, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantStarts := []time.Time{time.Date(2021, 3, 14, 0, 0, 0, 0, loc), time.Date(2021, 3, 14, 3, 0, 0, 0, loc), time.Date(2021, 3, 14, 5, 0, 0, 0, loc)}
+	if len(buckets) != len(wantStarts) {
+		t.Fatalf("BucketIndexByWallClock() 2h got %v buckets, want %v", buckets, len(wantStarts))
+	}
+	for i, b := range buckets {
+		if !b.Start.Equal(wantStarts[i]) || b.End.Sub(b.Start) != time.Hour*2 {
+			t.Errorf("BucketIndexByWallClock() 2h bucket %v = [%v, %v), want 2h from %v", i, b.Start, b.End, wantStarts[i])
+		}
+	}
+
+	//buckets are located directly rather than walked from a start time long before the samples, and gaps are jumped
+	sparse := []time.Time{time.Date(2021, 3, 14, 1, 59, 59, 0, loc), time.Date(2021, 3, 14, 3, 0, 0, 0, loc), time.Date(2021, 9, 1, 12, 0, 0, 0, loc)}
+	buckets, err = BucketIndexByWallClock(sparse, time.Second, loc, false, start.AddDate(-1, 0, 0))
+	if err != nil || len(buckets) != 3 || !buckets[1].Start.Equal(sparse[1]) || buckets[2].End.Sub(buckets[2].Start) != time.Second {
+		t.Errorf("BucketIndexByWallClock() with 1s buckets = %v, %v", buckets, err)
+	}
+	buckets, err = BucketIndexByWallClock(sparse, DurationDay(), loc, true, start.AddDate(-1, 0, 0))
+	if err != nil || len(buckets) != 172 || buckets[len(buckets)-1].Start.Hour() != 0 || buckets[len(buckets)-1].Count != 1 {
+		t.Errorf("BucketIndexByWallClock() daily with empty buckets got %v buckets, %v", len(buckets), err)
+	}
+	for i := 1; i < len(buckets); i++ {
+		if !buckets[i].Start.Equal(buckets[i-1].End) {
+			t.Errorf("BucketIndexByWallClock() daily bucket %v starts at %v, want %v", i, buckets[i].Start, buckets[i-1].End)
+			break
+		}
+	}
+}
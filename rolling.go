@@ -0,0 +1,216 @@
+package datetime
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+type rollingStat int
+
+const (
+	rollingSum rollingStat = iota
+	rollingCount
+	rollingMean
+	rollingStd
+	rollingMin
+	rollingMax
+)
+
+//rollingState keeps running aggregates of the values currently in a window
+//mean and m2 are updated with Welford's algorithm so samples can be removed without losing precision
+//minQ and maxQ are monotonic deques of positions, so the min and max of the window are always at their front
+type rollingState struct {
+	values []float64
+	count  int
+	sum    float64
+	mean   float64
+	m2     float64
+	minQ   []int
+	maxQ   []int
+}
+
+func (s *rollingState) add(j int) {
+	x := s.values[j]
+	if math.IsNaN(x) {
+		return
+	}
+	s.count++
+	s.sum += x
+	d := x - s.mean
+	s.mean += d / float64(s.count)
+	s.m2 += d * (x - s.mean)
+	for len(s.minQ) != 0 && s.values[s.minQ[len(s.minQ)-1]] >= x {
+		s.minQ = s.minQ[:len(s.minQ)-1]
+	}
+	s.minQ = append(s.minQ, j)
+	for len(s.maxQ) != 0 && s.values[s.maxQ[len(s.maxQ)-1]] <= x {
+		s.maxQ = s.maxQ[:len(s.maxQ)-1]
+	}
+	s.maxQ = append(s.maxQ, j)
+}
+
+func (s *rollingState) remove(j int) {
+	x := s.values[j]
+	if math.IsNaN(x) {
+		return
+	}
+	s.count--
+	s.sum -= x
+	if s.count == 0 {
+		s.mean, s.m2 = 0, 0
+	} else {
+		d := x - s.mean
+		s.mean -= d / float64(s.count)
+		s.m2 -= d * (x - s.mean)
+	}
+	if len(s.minQ) != 0 && s.minQ[0] == j {
+		s.minQ = s.minQ[1:]
+	}
+	if len(s.maxQ) != 0 && s.maxQ[0] == j {
+		s.maxQ = s.maxQ[1:]
+	}
+}
+
+func (s *rollingState) value(stat rollingStat) float64 {
+	switch stat {
+	case rollingSum:
+		return s.sum
+	case rollingCount:
+		return float64(s.count)
+	}
+	if s.count == 0 {
+		return math.NaN()
+	}
+	switch stat {
+	case rollingMean:
+		return s.mean
+	case rollingStd:
+		if s.count < 2 {
+			return math.NaN()
+		}
+		return math.Sqrt(math.Max(s.m2, 0) / float64(s.count-1))
+	case rollingMin:
+		return s.values[s.minQ[0]]
+	default:
+		return s.values[s.maxQ[0]]
+	}
+}
+
+func checkTimeSeries(fn string, index []time.Time, values []float64) error {
+	if len(index) != len(values) {
+		return fmt.Errorf("(%v) failed because of length mismatch, index has %v times and values %v", fn, len(index), len(values))
+	}
+	return checkSorted(fn, "index", index)
+}
+
+//rolling computes stat over the window (index[i]-window, index[i]] for every i, or over all samples up to i if expanding
+func rolling(fn string, index []time.Time, values []float64, window time.Duration, expanding bool, stat rollingStat) ([]float64, error) {
+	if err := checkTimeSeries(fn, index, values); err != nil {
+		return nil, err
+	}
+	if !expanding && window <= 0 {
+		return nil, fmt.Errorf("(%v) window must be positive, got %v", fn, window)
+	}
+	out := make([]float64, len(values))
+	state := &rollingState{values: values}
+	left := 0
+	for i := range values {
+		state.add(i)
+		for !expanding && !index[left].After(index[i].Add(-window)) {
+			state.remove(left)
+			left++
+		}
+		out[i] = state.value(stat)
+	}
+	return out, nil
+}
+
+//RollingSum returns for each sample the sum of values in the time window (t-window, t], rather than over the last N rows
+//index must be sorted but may be irregularly spaced. NaN values are skipped
+func RollingSum(index []time.Time, values []float64, window time.Duration) ([]float64, error) {
+	return rolling("RollingSum", index, values, window, false, rollingSum)
+}
+
+//RollingCount returns for each sample the number of non NaN values in the time window (t-window, t]
+func RollingCount(index []time.Time, values []float64, window time.Duration) ([]float64, error) {
+	return rolling("RollingCount", index, values, window, false, rollingCount)
+}
+
+//RollingMean returns for each sample the mean of values in the time window (t-window, t], NaN if the window is empty
+func RollingMean(index []time.Time, values []float64, window time.Duration) ([]float64, error) {
+	return rolling("RollingMean", index, values, window, false, rollingMean)
+}
+
+//RollingStd returns for each sample the sample standard deviation of values in the time window (t-window, t], NaN if it has less than 2 values
+func RollingStd(index []time.Time, values []float64, window time.Duration) ([]float64, error) {
+	return rolling("RollingStd", index, values, window, false, rollingStd)
+}
+
+//RollingMin returns for each sample the minimum of values in the time window (t-window, t], NaN if the window is empty
+func RollingMin(index []time.Time, values []float64, window time.Duration) ([]float64, error) {
+	return rolling("RollingMin", index, values, window, false, rollingMin)
+}
+
+//RollingMax returns for each sample the maximum of values in the time window (t-window, t], NaN if the window is empty
+func RollingMax(index []time.Time, values []float64, window time.Duration) ([]float64, error) {
+	return rolling("RollingMax", index, values, window, false, rollingMax)
+}
+
+//ExpandingSum returns for each sample the sum of all values up to and including it
+func ExpandingSum(index []time.Time, values []float64) ([]float64, error) {
+	return rolling("ExpandingSum", index, values, 0, true, rollingSum)
+}
+
+//ExpandingCount returns for each sample the number of non NaN values up to and including it
+func ExpandingCount(index []time.Time, values []float64) ([]float64, error) {
+	return rolling("ExpandingCount", index, values, 0, true, rollingCount)
+}
+
+//ExpandingMean returns for each sample the mean of all values up to and including it
+func ExpandingMean(index []time.Time, values []float64) ([]float64, error) {
+	return rolling("ExpandingMean", index, values, 0, true, rollingMean)
+}
+
+//ExpandingStd returns for each sample the sample standard deviation of all values up to and including it
+func ExpandingStd(index []time.Time, values []float64) ([]float64, error) {
+	return rolling("ExpandingStd", index, values, 0, true, rollingStd)
+}
+
+//ExpandingMin returns for each sample the minimum of all values up to and including it
+func ExpandingMin(index []time.Time, values []float64) ([]float64, error) {
+	return rolling("ExpandingMin", index, values, 0, true, rollingMin)
+}
+
+//ExpandingMax returns for each sample the maximum of all values up to and including it
+func ExpandingMax(index []time.Time, values []float64) ([]float64, error) {
+	return rolling("ExpandingMax", index, values, 0, true, rollingMax)
+}
+
+//EWMA returns the exponentially weighted moving average of values, where the weight of a sample halves every halfLife
+//decay depends on the time elapsed between samples, so irregular spacing is handled. NaN values are skipped
+func EWMA(index []time.Time, values []float64, halfLife time.Duration) ([]float64, error) {
+	if err := checkTimeSeries("EWMA", index, values); err != nil {
+		return nil, err
+	}
+	if halfLife <= 0 {
+		return nil, fmt.Errorf("(EWMA) half life must be positive, got %v", halfLife)
+	}
+	out := make([]float64, len(values))
+	avg := math.NaN()
+	var last time.Time
+	for i, x := range values {
+		switch {
+		case math.IsNaN(x):
+		case math.IsNaN(avg):
+			avg = x
+			last = index[i]
+		default:
+			alpha := 1 - math.Pow(0.5, float64(index[i].Sub(last))/float64(halfLife))
+			avg += alpha * (x - avg)
+			last = index[i]
+		}
+		out[i] = avg
+	}
+	return out, nil
+}
@@ -0,0 +1,81 @@
+package datetime
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func floatsEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.IsNaN(a[i]) && math.IsNaN(b[i]) {
+			continue
+		}
+		if math.Abs(a[i]-b[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRolling(t *testing.T) {
+	parsedTimes, _ := ParseDatetimeArray(times)
+	values := []float64{1, 2, 3, 4, 5, 6}
+	nan := math.NaN()
+	tests := []struct {
+		name string
+		fn   func([]time.Time, []float64, time.Duration) ([]float64, error)
+		want []float64
+	}{
+		{"sum", RollingSum, []float64{1, 3, 5, 4, 9, 6}},
+		{"count", RollingCount, []float64{1, 2, 2, 1, 2, 1}},
+		{"mean", RollingMean, []float64{1, 1.5, 2.5, 4, 4.5, 6}},
+		{"min", RollingMin, []float64{1, 1, 2, 4, 4, 6}},
+		{"max", RollingMax, []float64{1, 2, 3, 4, 5, 6}},
+		{"std", RollingStd, []float64{nan, math.Sqrt(0.5), math.Sqrt(0.5), nan, math.Sqrt(0.5), nan}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(parsedTimes, values, time.Minute*5)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if !floatsEqual(got, tt.want) {
+				t.Errorf("got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, err := RollingSum(parsedTimes, values[:2], time.Minute); err == nil {
+		t.Errorf("RollingSum() expected error for length mismatch")
+	}
+	if _, err := RollingSum([]time.Time{parsedTimes[1], parsedTimes[0]}, values[:2], time.Minute); err == nil {
+		t.Errorf("RollingSum() expected error for unsorted index")
+	}
+}
+
+func TestExpanding(t *testing.T) {
+	parsedTimes, _ := ParseDatetimeArray(times[:3])
+	got, _ := ExpandingMax(parsedTimes, []float64{2, 1, 3})
+	if want := []float64{2, 2, 3}; !floatsEqual(got, want) {
+		t.Errorf("ExpandingMax() = %v, want %v", got, want)
+	}
+	got, _ = ExpandingMean(parsedTimes, []float64{2, math.NaN(), 4})
+	if want := []float64{2, 2, 3}; !floatsEqual(got, want) {
+		t.Errorf("ExpandingMean() = %v, want %v", got, want)
+	}
+}
+
+func TestEWMA(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	index := []time.Time{start, start.Add(time.Hour), start.Add(time.Hour * 3)}
+	got, err := EWMA(index, []float64{0, 8, 8}, time.Hour)
+	if err != nil {
+		t.Fatalf("EWMA() error = %v", err)
+	}
+	if want := []float64{0, 4, 7}; !floatsEqual(got, want) {
+		t.Errorf("EWMA() = %v, want %v", got, want)
+	}
+}
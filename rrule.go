@@ -0,0 +1,683 @@
+package datetime
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//Freq is the FREQ of a recurrence rule, the period in which occurrences repeat
+type Freq int
+
+//Frequencies of RFC 5545, from longest to shortest period
+const (
+	FreqYearly Freq = iota
+	FreqMonthly
+	FreqWeekly
+	FreqDaily
+	FreqHourly
+	FreqMinutely
+	FreqSecondly
+)
+
+var freqNames = []string{"YEARLY", "MONTHLY", "WEEKLY", "DAILY", "HOURLY", "MINUTELY", "SECONDLY"}
+
+//String returns the RFC 5545 name of the frequency, like "MONTHLY"
+func (f Freq) String() string {
+	if f < 0 || int(f) >= len(freqNames) {
+		return fmt.Sprintf("Freq(%d)", int(f))
+	}
+	return freqNames[f]
+}
+
+var rruleWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func parseRRuleWeekday(s string) (time.Weekday, error) {
+	for i, name := range rruleWeekdays {
+		if s == name {
+			return time.Weekday(i), nil
+		}
+	}
+	return 0, fmt.Errorf("(ParseRRule) invalid weekday %q", s)
+}
+
+//RRuleWeekday is a BYDAY entry, a weekday and optionally which one of the month or year it is
+//N is 0 for every such weekday, 2 for the second and -1 for the last. N is only used with FreqMonthly and FreqYearly
+type RRuleWeekday struct {
+	Weekday time.Weekday
+	N       int
+}
+
+//String formats the weekday like "2TU" or "-1FR"
+func (w RRuleWeekday) String() string {
+	if w.N == 0 {
+		return rruleWeekdays[w.Weekday]
+	}
+	return strconv.Itoa(w.N) + rruleWeekdays[w.Weekday]
+}
+
+//RRule is an RFC 5545 recurrence rule, like FREQ=MONTHLY;BYDAY=2TU;COUNT=10 for the 2nd Tuesday of 10 months
+//occurrences are at the clock time of Dtstart, in its location, and Dtstart itself is only an occurrence if it matches the rule
+type RRule struct {
+	Freq Freq
+	//Interval is how many periods of Freq pass between occurrences, 1 if not positive
+	Interval int
+	//Count is the number of occurrences, unlimited if 0
+	Count int
+	//Until is the last time an occurrence may be at, unlimited if zero
+	Until      time.Time
+	ByDay      []RRuleWeekday
+	ByMonthDay []int
+	ByMonth    []time.Month
+	//BySetPos picks occurrences by position within each period, like -1 for the last
+	BySetPos []int
+	//Wkst is the first day of the week, Monday in RFC 5545. Set it with ParseRRule or explicitly
+	Wkst    time.Weekday
+	Dtstart time.Time
+}
+
+//rruleDateLayouts are the DATE-TIME and DATE forms of RFC 5545
+const (
+	rruleUTCLayout      = "20060102T150405Z"
+	rruleFloatingLayout = "20060102T150405"
+	rruleDateLayout     = "20060102"
+)
+
+//parseRRuleTime parses an RFC 5545 DATE-TIME or DATE, floating times are read in loc
+func parseRRuleTime(value string, loc *time.Location) (time.Time, error) {
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		return time.Parse(rruleUTCLayout, value)
+	case len(value) == len(rruleDateLayout):
+		return time.ParseInLocation(rruleDateLayout, value, loc)
+	}
+	return time.ParseInLocation(rruleFloatingLayout, value, loc)
+}
+
+func parseRRuleInts(name, value string, min, max int) ([]int, error) {
+	values := []int{}
+	for _, field := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < -max || n > max || (n > -min && n < min) {
+			return nil, fmt.Errorf("(ParseRRule) invalid %v value %q", name, field)
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+//ParseRRule parses a recurrence rule like "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", with or without an "RRULE:" prefix
+//FREQ, INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH, BYSETPOS and WKST are supported
+//dtstart is the first time of the recurrence, and the location of floating UNTIL times
+func ParseRRule(rule string, dtstart time.Time) (*RRule, error) {
+	r := &RRule{Interval: 1, Wkst: time.Monday, Dtstart: dtstart, Freq: -1}
+	rule = strings.TrimSpace(rule)
+	if strings.HasPrefix(strings.ToUpper(rule), "RRULE:") {
+		rule = rule[len("RRULE:"):]
+	}
+	for _, part := range strings.Split(rule, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("(ParseRRule) invalid rule part %q", part)
+		}
+		name, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq = -1
+			for i, f := range freqNames {
+				if value == f {
+					r.Freq = Freq(i)
+				}
+			}
+			if r.Freq < 0 {
+				err = fmt.Errorf("(ParseRRule) invalid FREQ %q", value)
+			}
+		case "INTERVAL":
+			if r.Interval, err = strconv.Atoi(value); err != nil || r.Interval < 1 {
+				err = fmt.Errorf("(ParseRRule) invalid INTERVAL %q", value)
+			}
+		case "COUNT":
+			if r.Count, err = strconv.Atoi(value); err != nil || r.Count < 1 {
+				err = fmt.Errorf("(ParseRRule) invalid COUNT %q", value)
+			}
+		case "UNTIL":
+			r.Until, err = parseRRuleTime(value, dtstart.Location())
+			if err == nil && len(value) == len(rruleDateLayout) {
+				//a DATE includes the whole day
+				r.Until = r.Until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+		case "BYDAY":
+			for _, field := range strings.Split(value, ",") {
+				w := RRuleWeekday{}
+				if len(field) < 2 {
+					return nil, fmt.Errorf("(ParseRRule) invalid BYDAY %q", field)
+				}
+				if len(field) > 2 {
+					if w.N, err = strconv.Atoi(field[:len(field)-2]); err != nil || w.N == 0 || w.N < -53 || w.N > 53 {
+						return nil, fmt.Errorf("(ParseRRule) invalid BYDAY %q", field)
+					}
+				}
+				if w.Weekday, err = parseRRuleWeekday(field[len(field)-2:]); err != nil {
+					return nil, err
+				}
+				r.ByDay = append(r.ByDay, w)
+			}
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseRRuleInts(name, value, 1, 31)
+		case "BYMONTH":
+			var months []int
+			if months, err = parseRRuleInts(name, value, 1, 12); err == nil {
+				for _, m := range months {
+					if m < 0 {
+						return nil, fmt.Errorf("(ParseRRule) invalid BYMONTH %v", m)
+					}
+					r.ByMonth = append(r.ByMonth, time.Month(m))
+				}
+			}
+		case "BYSETPOS":
+			r.BySetPos, err = parseRRuleInts(name, value, 1, 366)
+		case "WKST":
+			r.Wkst, err = parseRRuleWeekday(value)
+		default:
+			err = fmt.Errorf("(ParseRRule) unsupported rule part %v", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.Freq < 0 {
+		return nil, fmt.Errorf("(ParseRRule) FREQ is required in %q", rule)
+	}
+	if r.Count != 0 && !r.Until.IsZero() {
+		return nil, fmt.Errorf("(ParseRRule) COUNT and UNTIL must not both be set")
+	}
+	if r.BySetPos != nil && r.ByDay == nil && r.ByMonthDay == nil && r.ByMonth == nil {
+		return nil, fmt.Errorf("(ParseRRule) BYSETPOS needs another BY rule part")
+	}
+	return r, nil
+}
+
+//String formats the rule like "FREQ=MONTHLY;COUNT=10;BYDAY=2TU", without the "RRULE:" prefix or DTSTART
+func (r *RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count != 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(rruleUTCLayout))
+	}
+	join := func(name string, n int, field func(i int) string) {
+		if n == 0 {
+			return
+		}
+		fields := make([]string, n)
+		for i := range fields {
+			fields[i] = field(i)
+		}
+		parts = append(parts, name+"="+strings.Join(fields, ","))
+	}
+	join("BYMONTH", len(r.ByMonth), func(i int) string { return strconv.Itoa(int(r.ByMonth[i])) })
+	join("BYMONTHDAY", len(r.ByMonthDay), func(i int) string { return strconv.Itoa(r.ByMonthDay[i]) })
+	join("BYDAY", len(r.ByDay), func(i int) string { return r.ByDay[i].String() })
+	join("BYSETPOS", len(r.BySetPos), func(i int) string { return strconv.Itoa(r.BySetPos[i]) })
+	if r.Wkst != time.Monday {
+		parts = append(parts, "WKST="+rruleWeekdays[r.Wkst])
+	}
+	return strings.Join(parts, ";")
+}
+
+//withDefaults fills in the BY rule parts implied by Dtstart, like the day of month of a monthly rule
+func (r *RRule) withDefaults() RRule {
+	rule := *r
+	if rule.Interval < 1 {
+		rule.Interval = 1
+	}
+	if rule.ByDay == nil && rule.ByMonthDay == nil {
+		switch rule.Freq {
+		case FreqYearly:
+			if rule.ByMonth == nil {
+				rule.ByMonth = []time.Month{rule.Dtstart.Month()}
+			}
+			rule.ByMonthDay = []int{rule.Dtstart.Day()}
+		case FreqMonthly:
+			rule.ByMonthDay = []int{rule.Dtstart.Day()}
+		case FreqWeekly:
+			rule.ByDay = []RRuleWeekday{{Weekday: rule.Dtstart.Weekday()}}
+		}
+	}
+	return rule
+}
+
+//matches reports if the date of t is allowed by the BY rule parts
+func (r *RRule) matches(t time.Time) bool {
+	if r.ByMonth != nil {
+		found := false
+		for _, m := range r.ByMonth {
+			found = found || t.Month() == m
+		}
+		if !found {
+			return false
+		}
+	}
+	if r.ByMonthDay != nil {
+		found := false
+		days := DaysInMonth(t.Year(), t.Month())
+		for _, d := range r.ByMonthDay {
+			found = found || t.Day() == d || t.Day() == days+d+1
+		}
+		if !found {
+			return false
+		}
+	}
+	if r.ByDay == nil {
+		return true
+	}
+	for _, w := range r.ByDay {
+		if t.Weekday() != w.Weekday {
+			continue
+		}
+		if w.N == 0 || (r.Freq != FreqMonthly && r.Freq != FreqYearly) {
+			return true
+		}
+		//which one of the month, or of the year for yearly rules without BYMONTH, counted from the start and the end
+		position, days := t.Day(), DaysInMonth(t.Year(), t.Month())
+		if r.Freq == FreqYearly && r.ByMonth == nil {
+			position, days = t.YearDay(), time.Date(t.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+		}
+		if (w.N > 0 && (position-1)/7+1 == w.N) || (w.N < 0 && (days-position)/7+1 == -w.N) {
+			return true
+		}
+	}
+	return false
+}
+
+//subDailyUnits are the steps of the frequencies shorter than a day, which step in elapsed time from Dtstart
+var subDailyUnits = map[Freq]time.Duration{FreqHourly: time.Hour, FreqMinutely: time.Minute, FreqSecondly: time.Second}
+
+//periodDate returns the first date of the k-th period after the one holding Dtstart, as midnight UTC, and its length in days
+//for frequencies of a day or longer
+func (r *RRule) periodDate(k int) (time.Time, int) {
+	start := r.Dtstart
+	switch r.Freq {
+	case FreqYearly:
+		first := time.Date(start.Year()+k*r.Interval, time.January, 1, 0, 0, 0, 0, time.UTC)
+		return first, time.Date(first.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	case FreqMonthly:
+		first := time.Date(start.Year(), start.Month()+time.Month(k*r.Interval), 1, 0, 0, 0, 0, time.UTC)
+		return first, DaysInMonth(first.Year(), first.Month())
+	case FreqWeekly:
+		weekStart := start.Day() - (int(start.Weekday())-int(r.Wkst)+7)%7
+		return time.Date(start.Year(), start.Month(), weekStart+k*r.Interval*7, 0, 0, 0, 0, time.UTC), 7
+	}
+	return time.Date(start.Year(), start.Month(), start.Day()+k*r.Interval, 0, 0, 0, 0, time.UTC), 1
+}
+
+//atClock returns date at the clock time of Dtstart in its location
+//a clock time skipped by daylight saving moves forward by the gap, and a repeated one is the first of the two, see DSTCompatible
+func (r *RRule) atClock(date time.Time) time.Time {
+	start := r.Dtstart
+	wall := time.Date(date.Year(), date.Month(), date.Day(), start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), time.UTC)
+	t, _ := ResolveLocal(wall, start.Location(), DSTCompatible)
+	return t
+}
+
+//periodStart returns the start of the k-th period after the one holding Dtstart, at the clock time of Dtstart, and its length in days,
+//0 for frequencies shorter than a day, whose periods are a single time
+func (r *RRule) periodStart(k int) (time.Time, int) {
+	if unit, subDaily := subDailyUnits[r.Freq]; subDaily {
+		//in seconds, as the search reaches further than a time.Duration
+		seconds := int64(k*r.Interval) * int64(unit/time.Second)
+		return time.Unix(r.Dtstart.Unix()+seconds, int64(r.Dtstart.Nanosecond())).In(r.Dtstart.Location()), 0
+	}
+	date, days := r.periodDate(k)
+	return r.atClock(date), days
+}
+
+//period returns the candidate occurrences of the k-th period after the one holding Dtstart, sorted, with BYSETPOS applied
+func (r *RRule) period(k int) []time.Time {
+	candidates := []time.Time{}
+	if _, subDaily := subDailyUnits[r.Freq]; subDaily {
+		//sub daily frequencies step in elapsed time and only filter
+		if first, _ := r.periodStart(k); r.matches(first) {
+			candidates = append(candidates, first)
+		}
+	} else {
+		date, days := r.periodDate(k)
+		for d := 0; d < days; d++ {
+			if t := r.atClock(date.AddDate(0, 0, d)); r.matches(t) {
+				candidates = append(candidates, t)
+			}
+		}
+	}
+	if r.BySetPos == nil {
+		return candidates
+	}
+	picked := []time.Time{}
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(candidates) + pos
+		}
+		if i >= 0 && i < len(candidates) {
+			picked = append(picked, candidates[i])
+		}
+	}
+	sort.Slice(picked, func(i, j int) bool { return picked[i].Before(picked[j]) })
+	return picked
+}
+
+//maxSearchYears bounds the search for the next occurrence, so rules which never match, like February 30th, end
+//the calendar repeats every 400 years, so a rule which matched once matches again within them
+const maxSearchYears = 400
+
+//nextPeriod returns the period to look at after the empty period k
+//a sub daily period is empty because its date is not allowed, so the rest of the date is skipped rather than stepped through
+func (r *RRule) nextPeriod(k int) int {
+	t, days := r.periodStart(k)
+	if days != 0 {
+		return k + 1
+	}
+	step := time.Duration(r.Interval) * subDailyUnits[r.Freq]
+	nextDate := time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+	return k + int((nextDate.Sub(t)+step-1)/step)
+}
+
+//RRuleIterator lazily generates the occurrences of an RRule. It implements Iterator
+//rules without COUNT or UNTIL never end, so do not Collect them
+type RRuleIterator struct {
+	rule RRule
+	k    int
+	//last is the start of the last period with occurrences, the search ends maxSearchYears after it
+	last    time.Time
+	buffer  []time.Time
+	count   int
+	current time.Time
+	done    bool
+}
+
+//Iterator returns an iterator over the occurrences of the rule
+func (r *RRule) Iterator() *RRuleIterator {
+	rule := r.withDefaults()
+	return &RRuleIterator{rule: rule, last: rule.Dtstart}
+}
+
+//Next advances to the next occurrence, returning false once the rule has no more
+func (it *RRuleIterator) Next() bool {
+	for !it.done && len(it.buffer) == 0 {
+		start, _ := it.rule.periodStart(it.k)
+		if start.After(it.last.AddDate(maxSearchYears, 0, 0)) {
+			it.done = true
+			break
+		}
+		for _, t := range it.rule.period(it.k) {
+			if !t.Before(it.rule.Dtstart) {
+				it.buffer = append(it.buffer, t)
+			}
+		}
+		if len(it.buffer) == 0 {
+			it.k = it.rule.nextPeriod(it.k)
+		} else {
+			it.k++
+			it.last = start
+		}
+	}
+	if it.done {
+		return false
+	}
+	next := it.buffer[0]
+	if (it.rule.Count != 0 && it.count >= it.rule.Count) || (!it.rule.Until.IsZero() && next.After(it.rule.Until)) {
+		it.done = true
+		return false
+	}
+	it.buffer = it.buffer[1:]
+	it.current = next
+	it.count++
+	return true
+}
+
+//Time returns the current occurrence
+func (it *RRuleIterator) Time() time.Time {
+	return it.current
+}
+
+//Reset rewinds the iterator to the first occurrence
+func (it *RRuleIterator) Reset() {
+	*it = RRuleIterator{rule: it.rule, last: it.rule.Dtstart}
+}
+
+//CollectBetween drains an ascending Iterator, like an RRuleIterator, into a slice of the times in [after, before),
+//or [after, before] if inclusive is passed as true. It stops at the first time past before, so it works for rules which never end
+func CollectBetween(it Iterator, after, before time.Time, inclusive ...bool) []time.Time {
+	occurrences := []time.Time{}
+	for it.Next() {
+		t := it.Time()
+		if t.After(before) || (t.Equal(before) && (inclusive == nil || !inclusive[0])) {
+			break
+		}
+		if !t.Before(after) {
+			occurrences = append(occurrences, t)
+		}
+	}
+	return occurrences
+}
+
+//RRuleSet combines recurrence rules with extra dates (RDATE) and excluded dates (EXDATE), like an iCalendar event
+type RRuleSet struct {
+	Dtstart time.Time
+	RRules  []*RRule
+	RDates  []time.Time
+	ExDates []time.Time
+}
+
+//ParseRRuleSet parses DTSTART, RRULE, RDATE and EXDATE lines, like
+//
+//	DTSTART;TZID=America/New_York:20210105T090000
+//	RRULE:FREQ=WEEKLY;COUNT=10
+//	EXDATE;TZID=America/New_York:20210112T090000
+//
+//times with a TZID are read in that zone, floating times in the zone of DTSTART, and DTSTART defaults to UTC
+func ParseRRuleSet(text string) (*RRuleSet, error) {
+	set := &RRuleSet{}
+	rules := []string{}
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, params, value, err := parseContentLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("(ParseRRuleSet) %v", err)
+		}
+		switch name {
+		case "DTSTART":
+			times, err := parseContentTimes(value, params, time.UTC)
+			if err != nil || len(times) != 1 {
+				return nil, fmt.Errorf("(ParseRRuleSet) invalid DTSTART %q", value)
+			}
+			set.Dtstart = times[0]
+		case "RRULE":
+			rules = append(rules, value)
+		case "RDATE", "EXDATE":
+			loc := time.UTC
+			if !set.Dtstart.IsZero() {
+				loc = set.Dtstart.Location()
+			}
+			times, err := parseContentTimes(value, params, loc)
+			if err != nil {
+				return nil, fmt.Errorf("(ParseRRuleSet) invalid %v %q: %v", name, value, err)
+			}
+			if name == "RDATE" {
+				set.RDates = append(set.RDates, times...)
+			} else {
+				set.ExDates = append(set.ExDates, times...)
+			}
+		default:
+			return nil, fmt.Errorf("(ParseRRuleSet) unsupported property %v", name)
+		}
+	}
+	for _, rule := range rules {
+		r, err := ParseRRule(rule, set.Dtstart)
+		if err != nil {
+			return nil, err
+		}
+		set.RRules = append(set.RRules, r)
+	}
+	return set, nil
+}
+
+//parseContentLine splits an iCalendar content line like "DTSTART;TZID=Europe/Paris:20210101T090000" into its name, parameters and value
+func parseContentLine(line string) (string, map[string]string, string, error) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return "", nil, "", fmt.Errorf("line %q has no value", line)
+	}
+	head := strings.Split(line[:colon], ";")
+	params := map[string]string{}
+	for _, param := range head[1:] {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			return "", nil, "", fmt.Errorf("invalid parameter %q in line %q", param, line)
+		}
+		params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+	return strings.ToUpper(head[0]), params, line[colon+1:], nil
+}
+
+//parseContentTimes parses a comma separated list of DATE-TIME or DATE values, in the zone of a TZID parameter or loc
+func parseContentTimes(value string, params map[string]string, loc *time.Location) ([]time.Time, error) {
+	if tzid, exists := params["TZID"]; exists {
+		var err error
+		if loc, err = loadTZID(tzid); err != nil {
+			return nil, err
+		}
+	}
+	times := []time.Time{}
+	for _, field := range strings.Split(value, ",") {
+		t, err := parseRRuleTime(strings.TrimSpace(field), loc)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
+
+//loadTZID loads the location of a TZID parameter, an IANA zone or a Windows name from WindowsZoneNames
+func loadTZID(tzid string) (*time.Location, error) {
+	loc, err := time.LoadLocation(tzid)
+	if err == nil {
+		return loc, nil
+	}
+	if name, exists := WindowsZoneNames[tzid]; exists {
+		return time.LoadLocation(name)
+	}
+	return nil, err
+}
+
+//formatContentTimes formats times as a property line, with a TZID parameter unless they are in UTC
+func formatContentTimes(name string, times ...time.Time) string {
+	if len(times) == 0 {
+		return ""
+	}
+	loc := times[0].Location()
+	values := make([]string, len(times))
+	for i, t := range times {
+		if loc == time.UTC {
+			values[i] = t.UTC().Format(rruleUTCLayout)
+		} else {
+			values[i] = t.In(loc).Format(rruleFloatingLayout)
+		}
+	}
+	if loc == time.UTC {
+		return name + ":" + strings.Join(values, ",")
+	}
+	return name + ";TZID=" + loc.String() + ":" + strings.Join(values, ",")
+}
+
+//String formats the set as DTSTART, RRULE, RDATE and EXDATE lines, which ParseRRuleSet reads back
+func (s *RRuleSet) String() string {
+	lines := []string{}
+	if !s.Dtstart.IsZero() {
+		lines = append(lines, formatContentTimes("DTSTART", s.Dtstart))
+	}
+	for _, r := range s.RRules {
+		lines = append(lines, "RRULE:"+r.String())
+	}
+	if len(s.RDates) != 0 {
+		lines = append(lines, formatContentTimes("RDATE", s.RDates...))
+	}
+	if len(s.ExDates) != 0 {
+		lines = append(lines, formatContentTimes("EXDATE", s.ExDates...))
+	}
+	return strings.Join(lines, "\n")
+}
+
+//RRuleSetIterator lazily generates the occurrences of an RRuleSet in order, without duplicates or excluded dates. It implements Iterator
+type RRuleSetIterator struct {
+	rules   []*RRuleIterator
+	heads   []*time.Time
+	rdates  []time.Time
+	exdates map[int64]bool
+	current time.Time
+	started bool
+}
+
+//Iterator returns an iterator over the occurrences of the set
+func (s *RRuleSet) Iterator() *RRuleSetIterator {
+	it := &RRuleSetIterator{rdates: append([]time.Time{}, s.RDates...), exdates: map[int64]bool{}}
+	sort.Slice(it.rdates, func(i, j int) bool { return it.rdates[i].Before(it.rdates[j]) })
+	for _, t := range s.ExDates {
+		it.exdates[t.UnixNano()] = true
+	}
+	for _, r := range s.RRules {
+		ri := r.Iterator()
+		it.rules = append(it.rules, ri)
+		it.heads = append(it.heads, nil)
+	}
+	return it
+}
+
+//Next advances to the next occurrence of any rule or RDATE, skipping EXDATEs and repeats
+func (it *RRuleSetIterator) Next() bool {
+	for {
+		var next *time.Time
+		source := -1
+		for i, ri := range it.rules {
+			if it.heads[i] == nil && ri.Next() {
+				t := ri.Time()
+				it.heads[i] = &t
+			}
+			if it.heads[i] != nil && (next == nil || it.heads[i].Before(*next)) {
+				next, source = it.heads[i], i
+			}
+		}
+		if len(it.rdates) != 0 && (next == nil || it.rdates[0].Before(*next)) {
+			next, source = &it.rdates[0], -1
+		}
+		if next == nil {
+			return false
+		}
+		t := *next
+		if source < 0 {
+			it.rdates = it.rdates[1:]
+		} else {
+			it.heads[source] = nil
+		}
+		if it.exdates[t.UnixNano()] || (it.started && !t.After(it.current)) {
+			continue
+		}
+		it.current, it.started = t, true
+		return true
+	}
+}
+
+//Time returns the current occurrence
+func (it *RRuleSetIterator) Time() time.Time {
+	return it.current
+}
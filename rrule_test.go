@@ -0,0 +1,155 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRRuleOccurrences(t *testing.T) {
+	loc := newYork(t)
+	at := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, loc)
+	}
+	//examples from RFC 5545 section 3.8.5.3
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		limit   int
+		want    []time.Time
+		wantLen int
+	}{
+		{"daily 10 times", "FREQ=DAILY;COUNT=10", at(1997, 9, 2, 9), 0, nil, 10},
+		{"every other week", "FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;WKST=SU;BYDAY=MO,WE,FR", at(1997, 9, 1, 9), 0, nil, 25},
+		{"first friday", "FREQ=MONTHLY;COUNT=10;BYDAY=1FR", at(1997, 9, 5, 9), 0, []time.Time{
+			at(1997, 9, 5, 9), at(1997, 10, 3, 9), at(1997, 11, 7, 9), at(1997, 12, 5, 9), at(1998, 1, 2, 9),
+			at(1998, 2, 6, 9), at(1998, 3, 6, 9), at(1998, 4, 3, 9), at(1998, 5, 1, 9), at(1998, 6, 5, 9),
+		}, 10},
+		{"second tuesday", "RRULE:FREQ=MONTHLY;COUNT=3;BYDAY=2TU", at(2021, 1, 1, 9), 0, []time.Time{at(2021, 1, 12, 9), at(2021, 2, 9, 9), at(2021, 3, 9, 9)}, 3},
+		{"last weekday of month", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", at(1997, 9, 30, 9), 5, []time.Time{
+			at(1997, 9, 30, 9), at(1997, 10, 31, 9), at(1997, 11, 28, 9), at(1997, 12, 31, 9), at(1998, 1, 30, 9),
+		}, 5},
+		{"june and july", "FREQ=YEARLY;COUNT=4;BYMONTH=6,7", at(1997, 6, 10, 9), 0, []time.Time{at(1997, 6, 10, 9), at(1997, 7, 10, 9), at(1998, 6, 10, 9), at(1998, 7, 10, 9)}, 4},
+		{"20th monday of the year", "FREQ=YEARLY;BYDAY=20MO", at(1997, 5, 19, 9), 3, []time.Time{at(1997, 5, 19, 9), at(1998, 5, 18, 9), at(1999, 5, 17, 9)}, 3},
+		{"third to last day", "FREQ=MONTHLY;BYMONTHDAY=-3", at(1997, 9, 28, 9), 4, []time.Time{at(1997, 9, 28, 9), at(1997, 10, 29, 9), at(1997, 11, 28, 9), at(1997, 12, 29, 9)}, 4},
+		{"week starting monday", "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO", at(1997, 8, 5, 9), 0, []time.Time{at(1997, 8, 5, 9), at(1997, 8, 10, 9), at(1997, 8, 19, 9), at(1997, 8, 24, 9)}, 4},
+		{"week starting sunday", "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU", at(1997, 8, 5, 9), 0, []time.Time{at(1997, 8, 5, 9), at(1997, 8, 17, 9), at(1997, 8, 19, 9), at(1997, 8, 31, 9)}, 4},
+		{"every 3 hours", "FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T210000Z", at(1997, 9, 2, 9), 0, []time.Time{at(1997, 9, 2, 9), at(1997, 9, 2, 12), at(1997, 9, 2, 15)}, 3},
+		{"february 30th never happens", "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", at(2021, 1, 1, 9), 0, nil, 0},
+		{"february 30th never happens, every second", "FREQ=SECONDLY;BYMONTH=2;BYMONTHDAY=30", at(2021, 1, 1, 9), 0, nil, 0},
+		{"leap days only", "FREQ=DAILY;BYMONTH=2;BYMONTHDAY=29;COUNT=3", at(2021, 1, 1, 9), 0, []time.Time{at(2024, 2, 29, 9), at(2028, 2, 29, 9), at(2032, 2, 29, 9)}, 3},
+		{"every minute of mondays", "FREQ=MINUTELY;BYDAY=MO;COUNT=1000", at(2021, 1, 1, 9), 0, []time.Time{at(2021, 1, 4, 0), at(2021, 1, 4, 0).Add(time.Minute)}, 1000},
+		{"every hour of mondays", "FREQ=HOURLY;INTERVAL=5;BYDAY=MO;COUNT=6", at(2021, 1, 1, 9), 0, []time.Time{at(2021, 1, 4, 2), at(2021, 1, 4, 7), at(2021, 1, 4, 12), at(2021, 1, 4, 17), at(2021, 1, 4, 22), at(2021, 1, 11, 4)}, 6},
+		{"until a date", "FREQ=DAILY;UNTIL=20210103", at(2021, 1, 1, 9), 0, nil, 3},
+		//02:30 is skipped when clocks go forward on March 14th and moves forward by the gap, 01:30 is repeated on November 7th and is the first of the two
+		{"daily across the spring gap", "FREQ=DAILY;COUNT=3", time.Date(2021, 3, 13, 2, 30, 0, 0, loc), 0, []time.Time{
+			time.Date(2021, 3, 13, 2, 30, 0, 0, loc), time.Date(2021, 3, 14, 7, 30, 0, 0, time.UTC), time.Date(2021, 3, 15, 2, 30, 0, 0, loc),
+		}, 3},
+		{"daily across the autumn overlap", "FREQ=DAILY;COUNT=2", time.Date(2021, 11, 6, 1, 30, 0, 0, loc), 0, []time.Time{
+			time.Date(2021, 11, 6, 1, 30, 0, 0, loc), time.Date(2021, 11, 7, 5, 30, 0, 0, time.UTC),
+		}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRRule(tt.rule, tt.dtstart)
+			if err != nil {
+				t.Fatal(err)
+			}
+			it := r.Iterator()
+			got := []time.Time{}
+			for it.Next() && (tt.limit == 0 || len(got) < tt.limit) {
+				got = append(got, it.Time())
+			}
+			if len(got) != tt.wantLen {
+				t.Fatalf("RRule %v got %v occurrences, want %v: %v", tt.rule, len(got), tt.wantLen, got)
+			}
+			for i := range tt.want {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("RRule %v occurrence %v = %v, want %v", tt.rule, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseRRule(t *testing.T) {
+	dtstart := time.Date(2021, 1, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{"FREQ=MONTHLY;BYDAY=2TU;COUNT=10", "FREQ=MONTHLY;COUNT=10;BYDAY=2TU", false},
+		{"rrule:freq=weekly;interval=2;byday=mo,-1fr;wkst=su", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,-1FR;WKST=SU", false},
+		{"FREQ=YEARLY;UNTIL=20211231T000000Z;BYMONTH=1,6;BYMONTHDAY=1,-1;BYSETPOS=1,-1", "FREQ=YEARLY;UNTIL=20211231T000000Z;BYMONTH=1,6;BYMONTHDAY=1,-1;BYSETPOS=1,-1", false},
+		{"COUNT=10", "", true},
+		{"FREQ=FORTNIGHTLY", "", true},
+		{"FREQ=DAILY;COUNT=2;UNTIL=20211231T000000Z", "", true},
+		{"FREQ=DAILY;BYSETPOS=1", "", true},
+		{"FREQ=DAILY;BYHOUR=9", "", true},
+		{"FREQ=MONTHLY;BYDAY=0MO", "", true},
+		{"FREQ=MONTHLY;BYDAY=M", "", true},
+		{"FREQ=MONTHLY;BYMONTHDAY=32", "", true},
+		{"FREQ=MONTHLY;BYMONTH=13", "", true},
+		{"FREQ=DAILY;INTERVAL=0", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			r, err := ParseRRule(tt.rule, dtstart)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRRule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && r.String() != tt.want {
+				t.Errorf("RRule.String() = %v, want %v", r.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectBetween(t *testing.T) {
+	r, _ := ParseRRule("FREQ=WEEKLY;BYDAY=MO", time.Date(2021, 1, 4, 9, 0, 0, 0, time.UTC))
+	got := CollectBetween(r.Iterator(), time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC))
+	if len(got) != 4 || !got[0].Equal(time.Date(2021, 2, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("CollectBetween() = %v, want the 4 Mondays of February", got)
+	}
+	got = CollectBetween(r.Iterator(), time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC), true)
+	if len(got) != 5 {
+		t.Errorf("CollectBetween() inclusive = %v, want 5 Mondays", got)
+	}
+}
+
+func TestRRuleSet(t *testing.T) {
+	loc := newYork(t)
+	text := "DTSTART;TZID=America/New_York:20210105T090000\n" +
+		"RRULE:FREQ=WEEKLY;COUNT=4\n" +
+		"RRULE:FREQ=MONTHLY;COUNT=2;BYMONTHDAY=5\n" +
+		"RDATE;TZID=America/New_York:20210107T090000,20210105T090000\n" +
+		"EXDATE:20210112T140000Z"
+	set, err := ParseRRuleSet(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(month time.Month, day int) time.Time { return time.Date(2021, month, day, 9, 0, 0, 0, loc) }
+	want := []time.Time{at(1, 5), at(1, 7), at(1, 19), at(1, 26), at(2, 5)}
+	got := Collect(set.Iterator())
+	if len(got) != len(want) {
+		t.Fatalf("RRuleSet got %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("RRuleSet occurrence %v = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	reparsed, err := ParseRRuleSet(set.String())
+	if err != nil {
+		t.Fatalf("ParseRRuleSet(String()) error = %v for\n%v", err, set.String())
+	}
+	if again := Collect(reparsed.Iterator()); len(again) != len(want) {
+		t.Errorf("RRuleSet.String() does not round trip, got %v", again)
+	}
+	if _, err := ParseRRuleSet("DTSTART:20210105T090000Z\nSUMMARY:lunch"); err == nil {
+		t.Errorf("ParseRRuleSet() should fail on unsupported properties")
+	}
+}
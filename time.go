@@ -16,63 +16,48 @@ func ExtractDateFromDatetime(datetime time.Time) time.Time {
 	return time.Date(datetime.Year(), datetime.Month(), datetime.Day(), 0, 0, 0, 0, datetime.Location())
 }
 
-//ReplaceYear replaces only year with provided integer. If `with` is invalid, it will return original time
+//ReplaceYear replaces only year with provided integer. If the result is invalid, like February 29th in a non leap year, it will return original time
 func ReplaceYear(t time.Time, with int) time.Time {
-	if with > 12 || with < 0 {
-		logrus.Errorln("invalid replacement for month: must be between 0 and 12")
-		return t
-	}
-	return time.Date(t.Year(), time.Month(with), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	return buildOrLog(With(t).Year(with))
 }
 
 //ReplaceMonth replaces only month with provided integer. If `with` is invalid, it will return original time
 func ReplaceMonth(t time.Time, with int) time.Time {
-	if with > 12 || with < 0 {
-		logrus.Errorln("invalid replacement for month: must be between 0 and 12")
-		return t
-	}
-	return time.Date(t.Year(), time.Month(with), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	return buildOrLog(With(t).Month(time.Month(with)))
 }
 
-//ReplaceDay replaces only day with provided integer. If `with` is invalid, it will return original time
+//ReplaceDay replaces only day with provided integer. If `with` is invalid or not in the month, it will return original time
 func ReplaceDay(t time.Time, with int) time.Time {
-	if with > 31 || with < 1 {
-		logrus.Errorln("invalid replacement for day: must be between 1 and 31")
-		return t
-	}
-	return time.Date(t.Year(), t.Month(), with, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	return buildOrLog(With(t).Day(with))
 }
 
 //ReplaceHour replaces only hour with provided integer. If `with` is invalid, it will return original time
 func ReplaceHour(t time.Time, with int) time.Time {
-	if with > 23 || with < 0 {
-		logrus.Errorln("invalid replacement for hour: must be between 0 and 23")
-		return t
-	}
-	return time.Date(t.Year(), t.Month(), t.Day(), with, t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	return buildOrLog(With(t).Hour(with))
 }
 
 //ReplaceMinute replaces only minute with provided integer. If `with` is invalid, it will return original time
 func ReplaceMinute(t time.Time, with int) time.Time {
-	if with > 59 || with < 0 {
-		logrus.Errorln("invalid replacement for minute: must be between 0 and 59")
-		return t
-	}
-	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), with, t.Second(), t.Nanosecond(), t.Location())
+	return buildOrLog(With(t).Minute(with))
 }
 
 //ReplaceSecond replaces only second with provided integer. If `with` is invalid, it will return original time
 func ReplaceSecond(t time.Time, with int) time.Time {
-	if with > 59 || with < 0 {
-		logrus.Errorln("invalid replacement for second: must be between 0 and 59")
-		return t
-	}
-	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), with, t.Nanosecond(), t.Location())
+	return buildOrLog(With(t).Second(with))
 }
 
 //ReplaceNanosecond replaces only nsec with provided integer. If `with` is invalid, it will return original time
 func ReplaceNanosecond(t time.Time, with int) time.Time {
-	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), with, t.Location())
+	return buildOrLog(With(t).Nanosecond(with))
+}
+
+//buildOrLog builds b, logging and returning the original time on failure
+func buildOrLog(b *Builder) time.Time {
+	built, err := b.Build()
+	if err != nil {
+		logrus.Errorln(err)
+	}
+	return built
 }
 
 //ReplaceTimeInDatetime replaces time in date with hour min sec. Nothing else is changed
@@ -96,13 +81,27 @@ func StripTimezone(t time.Time) time.Time {
 	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
 }
 
-//DateIsEqual checks for year month and day equality
+//The *IsEqual and *IsEqualTo functions compare fields as read in the location of each time,
+//so the same instant in two timezones may compare unequal. The *In variants read both times in one location instead,
+//and InstantEqual compares instants regardless of location
+
+//InstantEqual reports if t1 and t2 are the same instant, in any locations. It is t1.Equal(t2)
+func InstantEqual(t1, t2 time.Time) bool {
+	return t1.Equal(t2)
+}
+
+//DateIsEqual checks for year month and day equality, each read in its own location
 func DateIsEqual(t1, t2 time.Time) bool {
 	return (t1.Year() == t2.Year()) && (t1.Month() == t2.Month()) && (t1.Day() == t2.Day())
 }
 
-//TimeIsEqual checks for hour, min, sec equality
-//if with nsec is passed, will also compare nano secs
+//DateIsEqualIn is DateIsEqual with both times read in loc
+func DateIsEqualIn(t1, t2 time.Time, loc *time.Location) bool {
+	return DateIsEqual(t1.In(loc), t2.In(loc))
+}
+
+//TimeIsEqual checks for hour, min, sec equality, each read in its own location
+//if withNsec is passed as true, will also compare nano secs
 func TimeIsEqual(t1, t2 time.Time, withNsec ...bool) bool {
 	nsec := true
 	if withNsec != nil && withNsec[0] {
@@ -111,8 +110,13 @@ func TimeIsEqual(t1, t2 time.Time, withNsec ...bool) bool {
 	return HourAndMinuteIsEqual(t1, t2) && SecondIsEqual(t1, t2) && nsec
 }
 
-//TimeIsEqualTo checks for hour, min, sec equality
-//if with nsec is passed, will also compare nano secs
+//TimeIsEqualIn is TimeIsEqual with both times read in loc
+func TimeIsEqualIn(t1, t2 time.Time, loc *time.Location, withNsec ...bool) bool {
+	return TimeIsEqual(t1.In(loc), t2.In(loc), withNsec...)
+}
+
+//TimeIsEqualTo checks if the hour, min, sec of t1 in its own location are the ones given
+//if nsec is passed, will also compare nano secs
 func TimeIsEqualTo(t1 time.Time, hour, min, sec int, nsec ...int) bool {
 	nsecs := true
 	if nsec != nil {
@@ -121,7 +125,13 @@ func TimeIsEqualTo(t1 time.Time, hour, min, sec int, nsec ...int) bool {
 	return t1.Hour() == hour && t1.Minute() == min && t1.Second() == sec && nsecs
 }
 
-//TimeIsGreaterThan checks if hour,min, sec are gt than time
+//TimeIsEqualToIn is TimeIsEqualTo with t1 read in loc
+func TimeIsEqualToIn(t1 time.Time, loc *time.Location, hour, min, sec int, nsec ...int) bool {
+	return TimeIsEqualTo(t1.In(loc), hour, min, sec, nsec...)
+}
+
+//TimeIsGreaterThan reports if the clock time hour:min:sec is after the clock time of t1, on the date of t1 in its own location
+//if nsec is not passed, it is 0
 func TimeIsGreaterThan(t1 time.Time, hour, min, sec int, nsec ...int) bool {
 	nsecs := 0
 	if nsec != nil {
@@ -131,7 +141,8 @@ func TimeIsGreaterThan(t1 time.Time, hour, min, sec int, nsec ...int) bool {
 	return t2.After(t1)
 }
 
-//TimeIsLessThan checks if hour,min, sec are gt than time
+//TimeIsLessThan reports if the clock time hour:min:sec is before the clock time of t1, on the date of t1 in its own location
+//if nsec is not passed, it is 0
 func TimeIsLessThan(t1 time.Time, hour, min, sec int, nsec ...int) bool {
 	nsecs := 0
 	if nsec != nil {
@@ -141,37 +152,66 @@ func TimeIsLessThan(t1 time.Time, hour, min, sec int, nsec ...int) bool {
 	return t2.Before(t1)
 }
 
-//DateIsEqualTo checks for hour, min, sec equality
-//if with nsec is passed, will also compare nano secs
+//DateIsEqualTo checks if the year, month and day of t1 in its own location are the ones given
 func DateIsEqualTo(t1 time.Time, year int, month time.Month, day int) bool {
 	return t1.Day() == day && t1.Month() == month && t1.Year() == year
 }
 
-//HourAndMinuteIsEqual checks for hour min equality
+//DateIsEqualToIn is DateIsEqualTo with t1 read in loc
+func DateIsEqualToIn(t1 time.Time, loc *time.Location, year int, month time.Month, day int) bool {
+	return DateIsEqualTo(t1.In(loc), year, month, day)
+}
+
+//HourAndMinuteIsEqual checks for hour min equality, each read in its own location
 func HourAndMinuteIsEqual(t1, t2 time.Time) bool {
 	return (t1.Hour() == t2.Hour()) && (t1.Minute() == t2.Minute())
 }
 
-//DayIsEqual returns true if day field is same
+//HourAndMinuteIsEqualIn is HourAndMinuteIsEqual with both times read in loc
+func HourAndMinuteIsEqualIn(t1, t2 time.Time, loc *time.Location) bool {
+	return HourAndMinuteIsEqual(t1.In(loc), t2.In(loc))
+}
+
+//DayIsEqual returns true if day of month field is same, each read in its own location
 func DayIsEqual(t1, t2 time.Time) bool {
 	return t1.Day() == t2.Day()
 }
 
-//HourIsEqual returns true if hour field is same
+//DayIsEqualIn is DayIsEqual with both times read in loc
+func DayIsEqualIn(t1, t2 time.Time, loc *time.Location) bool {
+	return DayIsEqual(t1.In(loc), t2.In(loc))
+}
+
+//HourIsEqual returns true if hour field is same, each read in its own location
 func HourIsEqual(t1, t2 time.Time) bool {
 	return t1.Hour() == t2.Hour()
 }
 
-//MinuteIsEqual returns true if minute field is same
+//HourIsEqualIn is HourIsEqual with both times read in loc
+func HourIsEqualIn(t1, t2 time.Time, loc *time.Location) bool {
+	return HourIsEqual(t1.In(loc), t2.In(loc))
+}
+
+//MinuteIsEqual returns true if minute field is same, each read in its own location
 func MinuteIsEqual(t1, t2 time.Time) bool {
 	return t1.Minute() == t2.Minute()
 }
 
-//SecondIsEqual returns true if second field is same
+//MinuteIsEqualIn is MinuteIsEqual with both times read in loc
+func MinuteIsEqualIn(t1, t2 time.Time, loc *time.Location) bool {
+	return MinuteIsEqual(t1.In(loc), t2.In(loc))
+}
+
+//SecondIsEqual returns true if second field is same, each read in its own location
 func SecondIsEqual(t1, t2 time.Time) bool {
 	return t1.Second() == t2.Second()
 }
 
+//SecondIsEqualIn is SecondIsEqual with both times read in loc
+func SecondIsEqualIn(t1, t2 time.Time, loc *time.Location) bool {
+	return SecondIsEqual(t1.In(loc), t2.In(loc))
+}
+
 //NowTime returns only time.Now() time field
 func NowTime() time.Time {
 	return ExtractTimeFromDatetime(time.Now())
@@ -197,13 +237,14 @@ func NowWithCustomTime(h, m, s int, nsec ...int) time.Time {
 	return ReplaceTimeInDatetime(NowDate(), h, m, s, nano)
 }
 
-//TimeIsInRange reports if t is between t1 and t2
+//DatetimeIsInRange reports if the instant t is between t1 and t2, in any locations
 //t1 is included and t2 is excluded
 func DatetimeIsInRange(t, t1, t2 time.Time) bool {
 	return !t.Before(t1) && t.Before(t2)
 }
 
-//TimeIsInArray checks if this exact time is in array
+//DatetimeIsInArray checks if this exact instant is in array, in any location
+//for large sorted arrays use TimeIndex.Contains instead
 func DatetimeIsInArray(t time.Time, ta []time.Time) bool {
 	for i := range ta {
 		if t.Equal(ta[i]) {
@@ -222,23 +263,14 @@ func DurationDay() time.Duration {
 func DurationWeek() time.Duration {
 	return time.Hour * 24 * 7
 }
-//MaxTime returns the maximum time in all supplied
+//MaxTime returns the maximum time in all supplied, or the zero time if none are. See MinMax
 func MaxTime(t ...time.Time) time.Time {
-	max := time.Time{}
-	for i := range t {
-		if t[i].After(max) {
-			max = t[i]
-		}
-	}
+	_, max, _, _, _ := MinMax(t)
 	return max
 }
-//MinTime returns the lowest time in all supplied
+
+//MinTime returns the lowest time in all supplied, or the zero time if none are. See MinMax
 func MinTime(t ...time.Time) time.Time {
-	min := time.Date(2099, time.January, 1, 1, 1, 1, 0, time.UTC)
-	for i := range t {
-		if t[i].Before(min) {
-			min = t[i]
-		}
-	}
+	min, _, _, _, _ := MinMax(t)
 	return min
 }
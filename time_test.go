@@ -0,0 +1,75 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComparisonsAcrossZones(t *testing.T) {
+	kolkata := time.FixedZone("+05:30", 19800)
+	newYork := time.FixedZone("-05:00", -18000)
+	//the same instant reads 2021-03-04 23:45:30 in Kolkata and 2021-03-04 13:15:30 in New York
+	instant := time.Date(2021, 3, 4, 18, 15, 30, 0, time.UTC)
+	inKolkata, inNewYork := instant.In(kolkata), instant.In(newYork)
+	//a different instant, on the next day in Kolkata but the same day in New York
+	later := time.Date(2021, 3, 5, 1, 0, 30, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"InstantEqual", InstantEqual(inKolkata, inNewYork), true},
+		{"InstantEqual different instants", InstantEqual(inKolkata, later), false},
+		{"DateIsEqual own locations", DateIsEqual(inNewYork, later.In(kolkata)), false},
+		{"DateIsEqualIn New York", DateIsEqualIn(inKolkata, later, newYork), true},
+		{"DateIsEqualIn Kolkata", DateIsEqualIn(inNewYork, later, kolkata), false},
+		{"TimeIsEqual own locations", TimeIsEqual(inKolkata, inNewYork), false},
+		{"TimeIsEqualIn", TimeIsEqualIn(inKolkata, inNewYork, time.UTC, true), true},
+		{"TimeIsEqualTo own location", TimeIsEqualTo(inKolkata, 23, 45, 30), true},
+		{"TimeIsEqualToIn", TimeIsEqualToIn(inKolkata, newYork, 13, 15, 30, 0), true},
+		{"DateIsEqualTo own location", DateIsEqualTo(inKolkata, 2021, time.March, 4), true},
+		{"DateIsEqualToIn", DateIsEqualToIn(later, kolkata, 2021, time.March, 5), true},
+		{"HourAndMinuteIsEqual own locations", HourAndMinuteIsEqual(inKolkata, inNewYork), false},
+		{"HourAndMinuteIsEqualIn", HourAndMinuteIsEqualIn(inKolkata, inNewYork, kolkata), true},
+		{"DayIsEqualIn", DayIsEqualIn(inKolkata, later, kolkata), false},
+		{"HourIsEqual own locations", HourIsEqual(inKolkata, inNewYork), false},
+		{"HourIsEqualIn", HourIsEqualIn(inKolkata, inNewYork, newYork), true},
+		{"MinuteIsEqual own locations", MinuteIsEqual(inKolkata, inNewYork), false},
+		{"MinuteIsEqualIn", MinuteIsEqualIn(inKolkata, inNewYork, time.UTC), true},
+		{"SecondIsEqualIn", SecondIsEqualIn(inKolkata, later, time.UTC), true},
+		{"DatetimeIsInRange across zones", DatetimeIsInRange(inKolkata, instant, later.In(newYork)), true},
+		{"DatetimeIsInArray across zones", DatetimeIsInArray(inNewYork, []time.Time{later, inKolkata}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%v = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeIsGreaterThan(t *testing.T) {
+	t1 := time.Date(2021, 3, 4, 9, 15, 0, 0, time.UTC)
+	if !TimeIsGreaterThan(t1, 10, 0, 0) || TimeIsGreaterThan(t1, 9, 0, 0) {
+		t.Errorf("TimeIsGreaterThan() should report if the given clock time is after 09:15")
+	}
+	if !TimeIsLessThan(t1, 9, 0, 0) || TimeIsLessThan(t1, 9, 15, 0) {
+		t.Errorf("TimeIsLessThan() should report if the given clock time is before 09:15")
+	}
+}
+
+func TestMinTimeMaxTime(t *testing.T) {
+	late := time.Date(2150, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := MinTime(later, late); !got.Equal(late) {
+		t.Errorf("MinTime() = %v, want %v", got, late)
+	}
+	if got := MaxTime(late, later); !got.Equal(later) {
+		t.Errorf("MaxTime() = %v, want %v", got, later)
+	}
+	if !MinTime().IsZero() || !MaxTime().IsZero() {
+		t.Errorf("MinTime() and MaxTime() of nothing should be the zero time")
+	}
+}
@@ -0,0 +1,8 @@
+//go:build datetime_tzdata
+// +build datetime_tzdata
+
+package datetime
+
+//building with -tags datetime_tzdata embeds a copy of the timezone database, about 450KB,
+//so zones load in minimal containers without /usr/share/zoneinfo
+import _ "time/tzdata"
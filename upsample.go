@@ -0,0 +1,127 @@
+package datetime
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+//FillMethod decides how Upsample computes values at grid times between samples
+type FillMethod int
+
+const (
+	//ForwardFill uses the last sample at or before the grid time
+	ForwardFill FillMethod = iota
+	//BackwardFill uses the first sample at or after the grid time
+	BackwardFill
+	//Nearest uses the closest sample, the earlier one on ties
+	Nearest
+	//Linear interpolates between the samples around the grid time, by elapsed time
+	Linear
+	//TimeWeighted averages the forward filled signal over each grid interval [grid[i], grid[i+1]), weighted by how long each value was held
+	//the last grid time uses the interval before it
+	TimeWeighted
+)
+
+//Upsample computes values at every time of grid from samples at irregular times in index, like GenerateTimeRange output from a coarser series
+//grid times which cannot be filled are NaN. If maxGap is provided and positive, a sample further than maxGap from the grid time,
+//or for Linear two samples further than maxGap apart, are not used so long gaps become missing instead of being filled
+func Upsample(index []time.Time, values []float64, grid []time.Time, method FillMethod, maxGap ...time.Duration) ([]float64, error) {
+	if err := checkTimeSeries("Upsample", index, values); err != nil {
+		return nil, err
+	}
+	if err := checkSorted("Upsample", "grid", grid); err != nil {
+		return nil, err
+	}
+	limit := time.Duration(math.MaxInt64)
+	if maxGap != nil && maxGap[0] > 0 {
+		limit = maxGap[0]
+	}
+	if method == TimeWeighted && len(grid) > 1 {
+		return upsampleTimeWeighted(index, values, grid, limit), nil
+	}
+
+	out := make([]float64, len(grid))
+	//after is the number of samples at or before the grid time, so index[after-1] <= g < index[after]
+	after := 0
+	for i, g := range grid {
+		out[i] = math.NaN()
+		for after < len(index) && !index[after].After(g) {
+			after++
+		}
+		prev, next := after-1, after
+		if prev >= 0 && index[prev].Equal(g) {
+			out[i] = values[prev]
+			continue
+		}
+		hasPrev := prev >= 0 && g.Sub(index[prev]) <= limit
+		hasNext := next < len(index) && index[next].Sub(g) <= limit
+		switch method {
+		case ForwardFill, TimeWeighted:
+			if hasPrev {
+				out[i] = values[prev]
+			}
+		case BackwardFill:
+			if hasNext {
+				out[i] = values[next]
+			}
+		case Nearest:
+			switch {
+			case hasPrev && (!hasNext || g.Sub(index[prev]) <= index[next].Sub(g)):
+				out[i] = values[prev]
+			case hasNext:
+				out[i] = values[next]
+			}
+		case Linear:
+			if prev >= 0 && next < len(index) && index[next].Sub(index[prev]) <= limit {
+				frac := float64(g.Sub(index[prev])) / float64(index[next].Sub(index[prev]))
+				out[i] = values[prev] + frac*(values[next]-values[prev])
+			}
+		default:
+			return nil, fmt.Errorf("(Upsample) unknown fill method %v", method)
+		}
+	}
+	return out, nil
+}
+
+func upsampleTimeWeighted(index []time.Time, values []float64, grid []time.Time, limit time.Duration) []float64 {
+	out := make([]float64, len(grid))
+	after := 0
+	for i, a := range grid {
+		var b time.Time
+		if i+1 < len(grid) {
+			b = grid[i+1]
+		} else {
+			b = a.Add(a.Sub(grid[i-1]))
+		}
+		for after < len(index) && !index[after].After(a) {
+			after++
+		}
+		held := after - 1
+		var weighted float64
+		var total time.Duration
+		for s := a; s.Before(b); {
+			e := b
+			if held+1 < len(index) && index[held+1].Before(b) {
+				e = index[held+1]
+			}
+			if held >= 0 {
+				end := e
+				if expires := index[held].Add(limit); limit != time.Duration(math.MaxInt64) && expires.Before(end) {
+					end = expires
+				}
+				if end.After(s) {
+					weighted += values[held] * float64(end.Sub(s))
+					total += end.Sub(s)
+				}
+			}
+			s = e
+			held++
+		}
+		out[i] = math.NaN()
+		if total > 0 {
+			out[i] = weighted / float64(total)
+		}
+	}
+	return out
+}
@@ -0,0 +1,43 @@
+package datetime
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestUpsample(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	index := []time.Time{start, start.Add(time.Minute * 4), start.Add(time.Minute * 20)}
+	values := []float64{0, 4, 20}
+	grid := GenerateTimeRange(start.Add(-time.Minute), time.Minute*3, 8)
+	nan := math.NaN()
+	type args struct {
+		method FillMethod
+		maxGap []time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want []float64
+	}{
+		{"forward fill", args{ForwardFill, nil}, []float64{nan, 0, 4, 4, 4, 4, 4, 20}},
+		{"forward fill max gap", args{ForwardFill, []time.Duration{time.Minute * 5}}, []float64{nan, 0, 4, 4, nan, nan, nan, 20}},
+		{"backward fill", args{BackwardFill, nil}, []float64{0, 4, 20, 20, 20, 20, 20, 20}},
+		{"nearest", args{Nearest, nil}, []float64{0, 0, 4, 4, 4, 20, 20, 20}},
+		{"linear", args{Linear, nil}, []float64{nan, 2, 5, 8, 11, 14, 17, 20}},
+		{"linear max gap", args{Linear, []time.Duration{time.Minute * 5}}, []float64{nan, 2, nan, nan, nan, nan, nan, 20}},
+		{"time weighted", args{TimeWeighted, nil}, []float64{0, 4.0 / 3, 4, 4, 4, 4, 4, 20}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Upsample(index, values, grid, tt.args.method, tt.args.maxGap...)
+			if err != nil {
+				t.Fatalf("Upsample() error = %v", err)
+			}
+			if !floatsEqual(got, tt.want) {
+				t.Errorf("Upsample() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package datetime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/araddon/dateparse"
+)
+
+//DefaultZonePreferences maps timezone abbreviations to the IANA zones they may mean, most likely first
+//abbreviations like IST or CST are used by several zones, so the order decides which one is picked
+var DefaultZonePreferences = map[string][]string{
+	"UTC":  {"UTC"},
+	"GMT":  {"Europe/London", "UTC"},
+	"Z":    {"UTC"},
+	"EST":  {"America/New_York"},
+	"EDT":  {"America/New_York"},
+	"CST":  {"America/Chicago", "Asia/Shanghai", "America/Havana"},
+	"CDT":  {"America/Chicago", "America/Havana"},
+	"MST":  {"America/Denver", "America/Phoenix"},
+	"MDT":  {"America/Denver"},
+	"PST":  {"America/Los_Angeles", "Asia/Manila"},
+	"PDT":  {"America/Los_Angeles"},
+	"AKST": {"America/Anchorage"},
+	"AKDT": {"America/Anchorage"},
+	"HST":  {"Pacific/Honolulu"},
+	"AST":  {"America/Halifax", "America/Puerto_Rico"},
+	"ADT":  {"America/Halifax"},
+	"BRT":  {"America/Sao_Paulo"},
+	"ART":  {"America/Argentina/Buenos_Aires"},
+	"BST":  {"Europe/London"},
+	"IST":  {"Asia/Kolkata", "Europe/Dublin", "Asia/Jerusalem"},
+	"WET":  {"Europe/Lisbon"},
+	"WEST": {"Europe/Lisbon"},
+	"CET":  {"Europe/Paris", "Europe/Berlin"},
+	"CEST": {"Europe/Paris", "Europe/Berlin"},
+	"EET":  {"Europe/Athens", "Europe/Helsinki"},
+	"EEST": {"Europe/Athens", "Europe/Helsinki"},
+	"MSK":  {"Europe/Moscow"},
+	"WAT":  {"Africa/Lagos"},
+	"CAT":  {"Africa/Maputo"},
+	"EAT":  {"Africa/Nairobi"},
+	"SAST": {"Africa/Johannesburg"},
+	"PKT":  {"Asia/Karachi"},
+	"ICT":  {"Asia/Bangkok"},
+	"WIB":  {"Asia/Jakarta"},
+	"SGT":  {"Asia/Singapore"},
+	"HKT":  {"Asia/Hong_Kong"},
+	"PHT":  {"Asia/Manila"},
+	"KST":  {"Asia/Seoul"},
+	"JST":  {"Asia/Tokyo"},
+	"AWST": {"Australia/Perth"},
+	"ACST": {"Australia/Adelaide", "Australia/Darwin"},
+	"ACDT": {"Australia/Adelaide"},
+	"AEST": {"Australia/Sydney", "Australia/Brisbane"},
+	"AEDT": {"Australia/Sydney"},
+	"NZST": {"Pacific/Auckland"},
+	"NZDT": {"Pacific/Auckland"},
+}
+
+//WindowsZoneNames maps the Windows timezone names some calendars use as TZID, like "Eastern Standard Time", to IANA zones
+//add to it for names it is missing
+var WindowsZoneNames = map[string]string{
+	"Dateline Standard Time":          "Etc/GMT+12",
+	"Hawaiian Standard Time":          "Pacific/Honolulu",
+	"Alaskan Standard Time":           "America/Anchorage",
+	"Pacific Standard Time":           "America/Los_Angeles",
+	"US Mountain Standard Time":       "America/Phoenix",
+	"Mountain Standard Time":          "America/Denver",
+	"Central Standard Time":           "America/Chicago",
+	"Central America Standard Time":   "America/Guatemala",
+	"Canada Central Standard Time":    "America/Regina",
+	"Eastern Standard Time":           "America/New_York",
+	"US Eastern Standard Time":        "America/Indiana/Indianapolis",
+	"SA Pacific Standard Time":        "America/Bogota",
+	"Atlantic Standard Time":          "America/Halifax",
+	"Newfoundland Standard Time":      "America/St_Johns",
+	"E. South America Standard Time":  "America/Sao_Paulo",
+	"Argentina Standard Time":         "America/Argentina/Buenos_Aires",
+	"UTC":                             "UTC",
+	"GMT Standard Time":               "Europe/London",
+	"Greenwich Standard Time":         "Atlantic/Reykjavik",
+	"W. Europe Standard Time":         "Europe/Berlin",
+	"Romance Standard Time":           "Europe/Paris",
+	"Central Europe Standard Time":    "Europe/Budapest",
+	"Central European Standard Time":  "Europe/Warsaw",
+	"W. Central Africa Standard Time": "Africa/Lagos",
+	"GTB Standard Time":               "Europe/Bucharest",
+	"FLE Standard Time":               "Europe/Kiev",
+	"Turkey Standard Time":            "Europe/Istanbul",
+	"Israel Standard Time":            "Asia/Jerusalem",
+	"Egypt Standard Time":             "Africa/Cairo",
+	"South Africa Standard Time":      "Africa/Johannesburg",
+	"E. Africa Standard Time":         "Africa/Nairobi",
+	"Russian Standard Time":           "Europe/Moscow",
+	"Arab Standard Time":              "Asia/Riyadh",
+	"Arabian Standard Time":           "Asia/Dubai",
+	"Iran Standard Time":              "Asia/Tehran",
+	"Pakistan Standard Time":          "Asia/Karachi",
+	"India Standard Time":             "Asia/Kolkata",
+	"Nepal Standard Time":             "Asia/Kathmandu",
+	"Bangladesh Standard Time":        "Asia/Dhaka",
+	"SE Asia Standard Time":           "Asia/Bangkok",
+	"China Standard Time":             "Asia/Shanghai",
+	"Singapore Standard Time":         "Asia/Singapore",
+	"Taipei Standard Time":            "Asia/Taipei",
+	"W. Australia Standard Time":      "Australia/Perth",
+	"Tokyo Standard Time":             "Asia/Tokyo",
+	"Korea Standard Time":             "Asia/Seoul",
+	"Cen. Australia Standard Time":    "Australia/Adelaide",
+	"AUS Central Standard Time":       "Australia/Darwin",
+	"E. Australia Standard Time":      "Australia/Brisbane",
+	"AUS Eastern Standard Time":       "Australia/Sydney",
+	"New Zealand Standard Time":       "Pacific/Auckland",
+}
+
+//ZoneResolver resolves timezone abbreviations to locations using a preference table, see DefaultZonePreferences
+//it is safe for concurrent use
+type ZoneResolver struct {
+	lock        sync.RWMutex
+	preferences map[string][]string
+}
+
+//NewZoneResolver creates a ZoneResolver with a copy of DefaultZonePreferences
+func NewZoneResolver() *ZoneResolver {
+	r := &ZoneResolver{preferences: map[string][]string{}}
+	for abbreviation, zones := range DefaultZonePreferences {
+		r.preferences[abbreviation] = append([]string{}, zones...)
+	}
+	return r
+}
+
+//DefaultZoneResolver is the ZoneResolver used by ParseDatetimeWithZone
+var DefaultZoneResolver = NewZoneResolver()
+
+//SetPreference makes zones the candidates of abbreviation, replacing any before, like SetPreference("IST", "Europe/Dublin")
+func (r *ZoneResolver) SetPreference(abbreviation string, zones ...string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.preferences[strings.ToUpper(abbreviation)] = append([]string{}, zones...)
+}
+
+//Candidates returns the IANA zones abbreviation may mean, most preferred first
+func (r *ZoneResolver) Candidates(abbreviation string) []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return append([]string{}, r.preferences[strings.ToUpper(abbreviation)]...)
+}
+
+//Resolve returns the location of the most preferred candidate of abbreviation which can be loaded
+//if at is provided, candidates which never use abbreviation in the year of at are skipped, and if none uses it the most preferred is returned.
+//a candidate using it only part of the year is kept, so PST in summer is America/Los_Angeles rather than Asia/Manila
+func (r *ZoneResolver) Resolve(abbreviation string, at ...time.Time) (*time.Location, error) {
+	candidates := r.Candidates(abbreviation)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("(ZoneResolver.Resolve) unknown timezone abbreviation %v", abbreviation)
+	}
+	var first *time.Location
+	for _, name := range candidates {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			continue
+		}
+		if len(at) == 0 || name == "UTC" {
+			return loc, nil
+		}
+		if _, uses := abbreviationOffset(loc, abbreviation, at[0]); uses {
+			return loc, nil
+		}
+		if first == nil {
+			first = loc
+		}
+	}
+	if first != nil {
+		return first, nil
+	}
+	return nil, fmt.Errorf("(ZoneResolver.Resolve) none of %v could be loaded for %v", candidates, abbreviation)
+}
+
+//abbreviationOffset returns the UTC offset in seconds loc has while it uses abbreviation, looking at at and then every day of its year
+func abbreviationOffset(loc *time.Location, abbreviation string, at time.Time) (int, bool) {
+	if name, offset := at.In(loc).Zone(); strings.EqualFold(name, abbreviation) {
+		return offset, true
+	}
+	day := time.Date(at.Year(), 1, 1, 12, 0, 0, 0, time.UTC)
+	for ; day.Year() == at.Year(); day = day.AddDate(0, 0, 1) {
+		if name, offset := day.In(loc).Zone(); strings.EqualFold(name, abbreviation) {
+			return offset, true
+		}
+	}
+	return 0, false
+}
+
+//ParseOffset parses a fixed UTC offset like "+05:30", "+0530", "-08", "Z" or "UTC+5:30" into a location named after it
+func ParseOffset(offset string) (*time.Location, error) {
+	s := strings.ToUpper(strings.TrimSpace(offset))
+	for _, prefix := range []string{"UTC", "GMT"} {
+		s = strings.TrimPrefix(s, prefix)
+	}
+	if s == "" || s == "Z" {
+		return time.UTC, nil
+	}
+	sign := 1
+	switch s[0] {
+	case '+':
+	case '-':
+		sign = -1
+	default:
+		return nil, fmt.Errorf("(ParseOffset) offset %q must start with + or -", offset)
+	}
+	s = s[1:]
+	hours, minutes := s, "0"
+	switch {
+	case strings.Contains(s, ":"):
+		parts := strings.SplitN(s, ":", 2)
+		hours, minutes = parts[0], parts[1]
+	case len(s) == 4:
+		hours, minutes = s[:2], s[2:]
+	}
+	h, errH := strconv.Atoi(hours)
+	m, errM := strconv.Atoi(minutes)
+	if errH != nil || errM != nil || h > 14 || m >= 60 || h < 0 || m < 0 {
+		return nil, fmt.Errorf("(ParseOffset) invalid offset %q", offset)
+	}
+	seconds := sign * (h*3600 + m*60)
+	if seconds == 0 {
+		return time.UTC, nil
+	}
+	return time.FixedZone(formatOffset(seconds), seconds), nil
+}
+
+//formatOffset formats an offset in seconds like +05:30
+func formatOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign, seconds = '-', -seconds
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+//zoneinfoDirs are where the timezone database is looked for, after the ZONEINFO environment variable
+var zoneinfoDirs = []string{"/usr/share/zoneinfo", "/usr/lib/zoneinfo", "/usr/share/lib/zoneinfo"}
+
+//zoneNames lists the IANA zone names of the timezone database on disk
+//if there is none, like in minimal containers using embedded tzdata, the zones of the embedded database are listed
+func zoneNames() []string {
+	dirs := zoneinfoDirs
+	if env := os.Getenv("ZONEINFO"); env != "" {
+		dirs = append([]string{env}, dirs...)
+	}
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		names := []string{}
+		filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			name, _ := filepath.Rel(dir, path)
+			if info.IsDir() {
+				//posix and right are copies of the database with other leap second handling
+				if name == "posix" || name == "right" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			//data files like zone.tab and leapseconds are not zones
+			if base := filepath.Base(name); strings.Contains(base, ".") || base[0] < 'A' || base[0] > 'Z' || base == "Factory" {
+				return nil
+			}
+			names = append(names, filepath.ToSlash(name))
+			return nil
+		})
+		if len(names) != 0 {
+			return names
+		}
+	}
+	return append([]string{}, tzdataZoneNames...)
+}
+
+var zoneLocationsOnce sync.Once
+var zoneLocations []*time.Location
+
+//loadZoneLocations loads every zone of zoneNames once, sorted by name, skipping zones which fail to load
+func loadZoneLocations() []*time.Location {
+	zoneLocationsOnce.Do(func() {
+		names := zoneNames()
+		sort.Strings(names)
+		for _, name := range names {
+			if loc, err := time.LoadLocation(name); err == nil {
+				zoneLocations = append(zoneLocations, loc)
+			}
+		}
+	})
+	return zoneLocations
+}
+
+//ZonesWithOffset lists the IANA zones whose UTC offset is offset at the time at, now if not provided, sorted by name
+//zones are read once from the timezone database on disk, or the embedded one if there is none, see tzdata.go
+func ZonesWithOffset(offset time.Duration, at ...time.Time) []string {
+	when := time.Now()
+	if len(at) != 0 {
+		when = at[0]
+	}
+	zones := []string{}
+	for _, loc := range loadZoneLocations() {
+		if _, seconds := when.In(loc).Zone(); time.Duration(seconds)*time.Second == offset {
+			zones = append(zones, loc.String())
+		}
+	}
+	return zones
+}
+
+//splitZoneSuffix splits a trailing timezone abbreviation or offset, like " IST" or " UTC+05:30", from datetime
+func splitZoneSuffix(datetime string) (string, string) {
+	datetime = strings.TrimSpace(datetime)
+	i := strings.LastIndex(datetime, " ")
+	if i < 0 {
+		return datetime, ""
+	}
+	suffix := datetime[i+1:]
+	isAbbreviation := len(suffix) >= 2 && len(suffix) <= 5 && strings.ToUpper(suffix) == suffix && strings.Trim(suffix, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") == ""
+	isOffset := strings.HasPrefix(suffix, "+") || strings.HasPrefix(suffix, "-") || strings.HasPrefix(suffix, "UTC") || strings.HasPrefix(suffix, "GMT")
+	if !isAbbreviation && !isOffset {
+		return datetime, ""
+	}
+	return strings.TrimSpace(datetime[:i]), suffix
+}
+
+//ParseDatetime is ParseDatetime for inputs ending with a timezone abbreviation or offset, like "2021-03-04 09:15 IST" or "2021-03-04 09:15 +05:30"
+//abbreviations are resolved with the preference table, checking the zone uses the abbreviation in that year. inputs without one are parsed in UTC
+func (r *ZoneResolver) ParseDatetime(datetime string) (time.Time, error) {
+	rest, suffix := splitZoneSuffix(datetime)
+	if suffix == "" {
+		return ParseDatetime(datetime)
+	}
+	loc, err := ParseOffset(suffix)
+	if err != nil {
+		if len(r.Candidates(suffix)) == 0 {
+			//not a zone after all, like a trailing AM or PM
+			return ParseDatetime(datetime)
+		}
+		approximate, parseErr := dateparse.ParseIn(rest, time.UTC)
+		if parseErr != nil {
+			return time.Time{}, parseErr
+		}
+		if loc, err = r.Resolve(suffix, approximate); err != nil {
+			return time.Time{}, err
+		}
+		//a zone using the abbreviation only part of the year, like PST written in summer, is read at the offset of the abbreviation
+		if inUse, _ := approximate.In(loc).Zone(); loc != time.UTC && !strings.EqualFold(inUse, suffix) {
+			if offset, uses := abbreviationOffset(loc, suffix, approximate); uses {
+				loc = time.FixedZone(strings.ToUpper(suffix), offset)
+			}
+		}
+	}
+	return dateparse.ParseIn(rest, loc)
+}
+
+//ParseDatetimeWithZone is ParseDatetime using DefaultZoneResolver for a trailing timezone abbreviation or offset
+func ParseDatetimeWithZone(datetime string) (time.Time, error) {
+	return DefaultZoneResolver.ParseDatetime(datetime)
+}
@@ -0,0 +1,155 @@
+package datetime
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestParseOffset(t *testing.T) {
+	tests := []struct {
+		offset  string
+		want    int
+		wantErr bool
+	}{
+		{"+05:30", 19800, false},
+		{"+0530", 19800, false},
+		{"-08", -28800, false},
+		{"-8:00", -28800, false},
+		{"UTC+5:30", 19800, false},
+		{"GMT-03", -10800, false},
+		{"Z", 0, false},
+		{"+00:00", 0, false},
+		{"05:30", 0, true},
+		{"+25:00", 0, true},
+		{"+05:75", 0, true},
+		{"+ab", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.offset, func(t *testing.T) {
+			loc, err := ParseOffset(tt.offset)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseOffset() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if _, got := time.Date(2021, 1, 1, 0, 0, 0, 0, loc).Zone(); got != tt.want {
+				t.Errorf("ParseOffset() offset = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if loc, _ := ParseOffset("+0530"); loc.String() != "+05:30" {
+		t.Errorf("ParseOffset() name = %v, want +05:30", loc)
+	}
+}
+
+func TestZoneResolver(t *testing.T) {
+	newYork(t)
+	r := NewZoneResolver()
+	if loc, err := r.Resolve("ist"); err != nil || loc.String() != "Asia/Kolkata" {
+		t.Errorf("Resolve(ist) = %v, %v, want Asia/Kolkata", loc, err)
+	}
+	r.SetPreference("IST", "Europe/Dublin", "Asia/Kolkata")
+	if loc, err := r.Resolve("IST", time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)); err != nil || loc.String() != "Europe/Dublin" {
+		t.Errorf("Resolve(IST) in summer = %v, %v, want Europe/Dublin", loc, err)
+	}
+	//Dublin uses GMT in winter, but IST in summer, so it stays the preferred zone
+	if loc, err := r.Resolve("IST", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil || loc.String() != "Europe/Dublin" {
+		t.Errorf("Resolve(IST) in winter = %v, %v, want Europe/Dublin", loc, err)
+	}
+	//New York never uses IST, so India is next
+	r.SetPreference("IST", "America/New_York", "Asia/Kolkata")
+	if loc, err := r.Resolve("IST", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil || loc.String() != "Asia/Kolkata" {
+		t.Errorf("Resolve(IST) = %v, %v, want Asia/Kolkata", loc, err)
+	}
+	//and when no candidate uses it, the first is kept
+	r.SetPreference("IST", "America/New_York", "America/Chicago")
+	if loc, err := r.Resolve("IST", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil || loc.String() != "America/New_York" {
+		t.Errorf("Resolve(IST) = %v, %v, want America/New_York", loc, err)
+	}
+	for abbreviation, want := range map[string]string{"PST": "America/Los_Angeles", "CST": "America/Chicago"} {
+		if loc, err := r.Resolve(abbreviation, time.Date(2021, 7, 4, 0, 0, 0, 0, time.UTC)); err != nil || loc.String() != want {
+			t.Errorf("Resolve(%v) in summer = %v, %v, want %v", abbreviation, loc, err, want)
+		}
+	}
+	if DefaultZoneResolver.Candidates("IST")[0] != "Asia/Kolkata" {
+		t.Errorf("SetPreference() changed DefaultZonePreferences")
+	}
+	if _, err := r.Resolve("XYZ"); err == nil {
+		t.Errorf("Resolve(XYZ) should fail")
+	}
+}
+
+func TestParseDatetimeWithZone(t *testing.T) {
+	newYork(t)
+	tests := []struct {
+		datetime string
+		want     time.Time
+		wantErr  bool
+	}{
+		{"2021-03-04 09:15 IST", time.Date(2021, 3, 4, 3, 45, 0, 0, time.UTC), false},
+		{"2021-03-04 09:15 +05:30", time.Date(2021, 3, 4, 3, 45, 0, 0, time.UTC), false},
+		{"2021-03-04 09:15 UTC+5:30", time.Date(2021, 3, 4, 3, 45, 0, 0, time.UTC), false},
+		{"2021-07-04 09:15 EDT", time.Date(2021, 7, 4, 13, 15, 0, 0, time.UTC), false},
+		{"2021-01-04 09:15 EST", time.Date(2021, 1, 4, 14, 15, 0, 0, time.UTC), false},
+		{"2021-07-04 09:15 PST", time.Date(2021, 7, 4, 17, 15, 0, 0, time.UTC), false},
+		{"2021-07-04 09:15 CST", time.Date(2021, 7, 4, 15, 15, 0, 0, time.UTC), false},
+		{"2021-03-04 09:15", time.Date(2021, 3, 4, 9, 15, 0, 0, time.UTC), false},
+		{"not a date IST", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.datetime, func(t *testing.T) {
+			got, err := ParseDatetimeWithZone(tt.datetime)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDatetimeWithZone() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseDatetimeWithZone() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZonesWithOffset(t *testing.T) {
+	newYork(t)
+	got := ZonesWithOffset(time.Hour*5+time.Minute*30, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	found := false
+	for _, name := range got {
+		if name == "Asia/Kolkata" {
+			found = true
+		}
+		if name == "America/New_York" {
+			t.Errorf("ZonesWithOffset() included %v", name)
+		}
+	}
+	if !found {
+		t.Errorf("ZonesWithOffset() = %v, want Asia/Kolkata included", got)
+	}
+	//zones are loaded once and reused
+	if first, second := loadZoneLocations(), loadZoneLocations(); len(first) == 0 || &first[0] != &second[0] {
+		t.Errorf("loadZoneLocations() loaded %v zones, and again on the second call", len(first))
+	}
+}
+
+func TestZoneNamesWithoutDatabase(t *testing.T) {
+	dirs, env := zoneinfoDirs, os.Getenv("ZONEINFO")
+	zoneinfoDirs = []string{filepath.Join(t.TempDir(), "missing")}
+	os.Setenv("ZONEINFO", "")
+	defer func() {
+		zoneinfoDirs = dirs
+		os.Setenv("ZONEINFO", env)
+	}()
+	names := zoneNames()
+	if len(names) != len(tzdataZoneNames) {
+		t.Fatalf("zoneNames() without a database on disk got %v zones, want the %v embedded ones", len(names), len(tzdataZoneNames))
+	}
+	sort.Strings(names)
+	if i := sort.SearchStrings(names, "Asia/Kolkata"); i == len(names) || names[i] != "Asia/Kolkata" {
+		t.Errorf("zoneNames() without a database on disk is missing Asia/Kolkata")
+	}
+}